@@ -0,0 +1,222 @@
+package curve25519
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ellemouton/schnorr/ellipticcurve"
+	"github.com/ellemouton/schnorr/finitefield"
+)
+
+const (
+	// p is the prime 2^255 - 19 of the field.
+	p = "7FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFED"
+
+	// l is the prime order of the subgroup generated by the base points,
+	// 2^252 + 27742317777372353535851937790883648493.
+	l = "1000000000000000000000000000000014DEF9DEA2F79CD65812631A5CF5D3ED"
+
+	// edX and edY are the coordinates of the Ed25519 base point. edY is
+	// 4/5.
+	edX = "216936D3CD6E53FEC0A4E231FDD6DC5C692CC7609525A7B2C9562D608F25D51A"
+	edY = "6666666666666666666666666666666666666666666666666666666666666658"
+
+	// montgomeryA is the coefficient A of Curve25519.
+	montgomeryA = 486662
+
+	// baseU is the X coordinate of the Curve25519 base point.
+	baseU = 9
+
+	// baseV is the Y coordinate of the Curve25519 base point given in
+	// section 4.1 of RFC 7748.
+	baseV = "20AE19A1B8A086B4E01EDD2C7748D14C923D4D7E6D7C61B229E9C5A27ECED3D9"
+
+	// cofactor is the cofactor of both curves.
+	cofactor = 8
+
+	// X25519Size is the length in bytes of the scalars, X coordinates and
+	// shared secrets of X25519.
+	X25519Size = 32
+)
+
+// ErrInvalidLength is returned when X25519 is given a scalar or an X coordinate
+// that is not X25519Size bytes long.
+var ErrInvalidLength = errors.New("x25519 inputs must be 32 bytes long")
+
+var (
+	// P is the prime 2^255 - 19.
+	P *big.Int
+
+	// L is the prime order of the subgroup generated by the base points of
+	// both curves.
+	L *big.Int
+
+	// BaseField is the finite field of order P that both curves are
+	// defined over.
+	BaseField *finitefield.Field
+
+	// Curve is Curve25519, the Montgomery curve y^2 = x^3 + 486662x^2 + x
+	// from RFC 7748.
+	Curve *ellipticcurve.MontgomeryCurve
+
+	// BaseU is the X coordinate of the Curve25519 base point.
+	BaseU *finitefield.Element
+
+	// BaseV is the Y coordinate of the Curve25519 base point.
+	BaseV *finitefield.Element
+
+	// sqrtM486664 is the square root of -486664 used by the map between
+	// Ed25519 and Curve25519.
+	sqrtM486664 *finitefield.Element
+
+	// Ed25519 is the twisted Edwards curve -x^2 + y^2 = 1 + dx^2y^2 with
+	// d = -121665/121666 from RFC 8032. It is birationally equivalent to
+	// Curve25519. Its generator is the Ed25519 base point.
+	Ed25519 *ellipticcurve.EdwardsCurve
+)
+
+func init() {
+	P = hexInt(p)
+	L = hexInt(l)
+
+	var err error
+	BaseField, err = finitefield.NewField(P)
+	if err != nil {
+		panic("could not init base field: " + err.Error())
+	}
+
+	Curve, err = ellipticcurve.NewMontgomeryCurve(
+		BaseField.FromInt(montgomeryA), BaseField.One(),
+	)
+	if err != nil {
+		panic("error initializing curve25519: " + err.Error())
+	}
+
+	BaseU = BaseField.FromInt(baseU)
+	BaseV = BaseField.FromBigInt(hexInt(baseV))
+
+	// d = -121665 / 121666
+	d := BaseField.FromInt(-121665)
+	d.SetDiv(d, BaseField.FromInt(121666))
+
+	Ed25519, err = ellipticcurve.NewEdwardsCurve(BaseField.FromInt(-1), d)
+	if err != nil {
+		panic("error initializing ed25519: " + err.Error())
+	}
+
+	x := BaseField.FromBigInt(hexInt(edX))
+	y := BaseField.FromBigInt(hexInt(edY))
+
+	Ed25519, err = Ed25519.WithGenerator(x, y, L, big.NewInt(cofactor))
+	if err != nil {
+		panic("error initializing ed25519 generator: " + err.Error())
+	}
+
+	// The curve that Ed25519 is mapped to by ToMontgomery has
+	// B = 4 / (a - d) = -486664, so its Y coordinates are those of
+	// Curve25519 divided by a square root of -486664. The root is the one
+	// that maps the Ed25519 base point to the Curve25519 one.
+	sqrtM486664 = new(finitefield.Element).SetDiv(
+		BaseV, Ed25519.G().ToMontgomery().Y(),
+	)
+}
+
+// FromEd25519 maps a point on Ed25519 to the equivalent point on Curve25519
+// with the map of section 4.1 of RFC 7748:
+//
+//	(x, y) -> ((1 + y) / (1 - y), sqrt(-486664) * u / x)
+//
+// Unlike EdwardsPoint.ToMontgomery, whose curve has B = -486664, the result is
+// on Curve, which has B = 1. ErrPointsNotOnSameCurve is returned if the point
+// is not on Ed25519.
+func FromEd25519(p *ellipticcurve.EdwardsPoint) (*ellipticcurve.MontgomeryPoint,
+	error) {
+
+	if !p.EdwardsCurve.Equal(Ed25519) {
+		return nil, ellipticcurve.ErrPointsNotOnSameCurve
+	}
+
+	m := p.ToMontgomery()
+	if m.IsInfinity {
+		return ellipticcurve.NewMontgomeryInfinityPoint(Curve), nil
+	}
+
+	v := new(finitefield.Element).SetMul(m.Y(), sqrtM486664)
+
+	return ellipticcurve.NewMontgomeryPoint(m.X(), v, Curve)
+}
+
+// ToEd25519 maps a point on Curve25519 to the equivalent point on Ed25519. It
+// is the inverse of FromEd25519. ErrPointsNotOnSameCurve is returned if the
+// point is not on Curve.
+func ToEd25519(p *ellipticcurve.MontgomeryPoint) (*ellipticcurve.EdwardsPoint,
+	error) {
+
+	if !p.MontgomeryCurve.Equal(Curve) {
+		return nil, ellipticcurve.ErrPointsNotOnSameCurve
+	}
+
+	if p.IsInfinity {
+		return ellipticcurve.NewEdwardsIdentity(Ed25519), nil
+	}
+
+	v := new(finitefield.Element).SetDiv(p.Y(), sqrtM486664)
+
+	m, err := ellipticcurve.NewMontgomeryPoint(
+		p.X(), v, Ed25519.Montgomery(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return Ed25519.FromMontgomery(m)
+}
+
+// X25519 computes the X25519 function of RFC 7748 on the little-endian scalar
+// and X coordinate. The scalar is clamped first, and the top bit of the X
+// coordinate is ignored. With BaseU as the X coordinate, it computes a public
+// key, and with a public key it computes a Diffie-Hellman shared secret.
+//
+// NOTE: Ladder does not run in constant time so this is for experimenting with
+// the curve and must not be used with real secret keys.
+func X25519(scalar, u []byte) ([]byte, error) {
+	if len(scalar) != X25519Size || len(u) != X25519Size {
+		return nil, ErrInvalidLength
+	}
+
+	var k [X25519Size]byte
+	copy(k[:], scalar)
+	k[0] &= 248
+	k[31] &= 127
+	k[31] |= 64
+
+	var x [X25519Size]byte
+	copy(x[:], u)
+	x[31] &= 127
+
+	r := Curve.Ladder(
+		new(big.Int).SetBytes(reverse(k[:])),
+		BaseField.FromBigInt(new(big.Int).SetBytes(reverse(x[:]))),
+	)
+
+	return r.LittleEndianBytes(), nil
+}
+
+// reverse reverses the bytes of b in place and returns b.
+func reverse(b []byte) []byte {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+
+	return b
+}
+
+// hexInt parses the given hex constant. It panics if the constant is invalid.
+func hexInt(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("invalid hex: " + s)
+	}
+
+	return n
+}
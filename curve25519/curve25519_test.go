@@ -0,0 +1,187 @@
+package curve25519
+
+import (
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ellemouton/schnorr/ellipticcurve"
+	"github.com/ellemouton/schnorr/finitefield"
+	"github.com/stretchr/testify/require"
+)
+
+// TestX25519 checks X25519 against the test vectors of section 5.2 of RFC
+// 7748.
+func TestX25519(t *testing.T) {
+	tests := []struct {
+		scalar, u, out string
+	}{
+		{
+			scalar: "a546e36bf0527c9d3b16154b82465edd62144c0ac1fc5a18506a" +
+				"2244ba449ac4",
+			u: "e6db6867583030db3594c1a424b15f7c726624ec26b3353b10a903a6" +
+				"d0ab1c4c",
+			out: "c3da55379de9c6908e94ea4df28d084f32eccf03491c71f754b40755" +
+				"77a28552",
+		},
+		{
+			scalar: "4b66e9d4d1b4673c5ad22691957d6af5c11b6421e0ea01d42ca4" +
+				"169e7918ba0d",
+			u: "e5210f12786811d3f4b7959d0538ae2c31dbe7106fc03c3efc4cd549" +
+				"c715a493",
+			out: "95cbde9476e8907d7aade45cb4b873f88b595a68799fa152e6f8f764" +
+				"7aac7957",
+		},
+	}
+
+	for _, test := range tests {
+		scalar, err := hex.DecodeString(test.scalar)
+		require.NoError(t, err)
+
+		u, err := hex.DecodeString(test.u)
+		require.NoError(t, err)
+
+		out, err := X25519(scalar, u)
+		require.NoError(t, err)
+		require.Equal(t, test.out, hex.EncodeToString(out))
+	}
+
+	_, err := X25519(make([]byte, 31), make([]byte, 32))
+	require.ErrorIs(t, err, ErrInvalidLength)
+}
+
+// TestX25519ECDH checks X25519 against crypto/ecdh for random keys.
+func TestX25519ECDH(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		a, err := ecdh.X25519().GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		b, err := ecdh.X25519().GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		pub, err := X25519(a.Bytes(), BaseU.LittleEndianBytes())
+		require.NoError(t, err)
+		require.Equal(t, a.PublicKey().Bytes(), pub)
+
+		want, err := a.ECDH(b.PublicKey())
+		require.NoError(t, err)
+
+		shared, err := X25519(a.Bytes(), b.PublicKey().Bytes())
+		require.NoError(t, err)
+		require.Equal(t, want, shared)
+	}
+}
+
+// TestEd25519 checks that multiples of the Ed25519 base point match the public
+// keys of crypto/ed25519.
+func TestEd25519(t *testing.T) {
+	for i := 0; i < 5; i++ {
+		seed := make([]byte, ed25519.SeedSize)
+		_, err := rand.Read(seed)
+		require.NoError(t, err)
+
+		// The secret scalar is the clamped first half of the hash of
+		// the seed, as described in section 5.1.5 of RFC 8032.
+		h := sha512.Sum512(seed)
+		h[0] &= 248
+		h[31] &= 127
+		h[31] |= 64
+		s := new(big.Int).SetBytes(reverse(h[:32]))
+
+		pub := ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)
+		require.Equal(t, []byte(pub), encodeEdwards(Ed25519.G().ScalarMult(s)))
+	}
+}
+
+// TestBirationalMaps checks that Ed25519 and Curve25519 agree through the maps
+// between the curve forms.
+func TestBirationalMaps(t *testing.T) {
+	// The Ed25519 base point is mapped to the Curve25519 one.
+	g := Ed25519.G().ToMontgomery()
+	require.True(t, g.X().Equal(BaseU))
+
+	k, err := rand.Int(rand.Reader, L)
+	require.NoError(t, err)
+
+	// The ladder, which only uses A, agrees with Edwards arithmetic.
+	p := Ed25519.G().ScalarMult(k)
+	require.True(t, Curve.Ladder(k, BaseU).Equal(p.ToMontgomery().X()))
+
+	// So does Weierstrass arithmetic.
+	w := Ed25519.G().ToWeierstrass().ScalarMult(k)
+	m, err := Ed25519.Montgomery().FromWeierstrass(w)
+	require.NoError(t, err)
+
+	e, err := Ed25519.FromMontgomery(m)
+	require.NoError(t, err)
+	require.True(t, p.Equal(e))
+
+	// The base point has order L.
+	require.True(t, Ed25519.G().ScalarMult(L).IsIdentity())
+	require.True(t, Curve.Ladder(L, BaseU).IsZero())
+}
+
+// TestRFC7748Map checks the map between Ed25519 and Curve25519 of section 4.1
+// of RFC 7748 and that points survive a round trip through Curve.
+func TestRFC7748Map(t *testing.T) {
+	// The root used by the map is a square root of -486664.
+	sq := new(finitefield.Element).SetSquare(sqrtM486664)
+	require.True(t, sq.Equal(BaseField.FromInt(-486664)))
+
+	// The Ed25519 base point is mapped to (BaseU, BaseV).
+	base, err := ellipticcurve.NewMontgomeryPoint(BaseU, BaseV, Curve)
+	require.NoError(t, err)
+
+	g, err := FromEd25519(Ed25519.G())
+	require.NoError(t, err)
+	require.True(t, g.Equal(base))
+
+	e, err := ToEd25519(base)
+	require.NoError(t, err)
+	require.True(t, e.Equal(Ed25519.G()))
+
+	// The identity, the point of order 2 and random points make the
+	// round trip.
+	order2, err := ellipticcurve.NewEdwardsPoint(
+		BaseField.Zero(), BaseField.FromInt(-1), Ed25519,
+	)
+	require.NoError(t, err)
+
+	points := []*ellipticcurve.EdwardsPoint{
+		ellipticcurve.NewEdwardsIdentity(Ed25519), order2,
+	}
+	for i := 0; i < 5; i++ {
+		k, err := rand.Int(rand.Reader, L)
+		require.NoError(t, err)
+
+		points = append(points, Ed25519.G().ScalarMult(k))
+	}
+
+	for _, p := range points {
+		m, err := FromEd25519(p)
+		require.NoError(t, err)
+		require.True(t, m.MontgomeryCurve.Equal(Curve))
+		require.True(t, m.IsInfinity || Curve.Contains(m.X(), m.Y()))
+
+		e, err := ToEd25519(m)
+		require.NoError(t, err)
+		require.True(t, p.Equal(e))
+	}
+
+	// Points of the other forms of the curves are rejected.
+	_, err = ToEd25519(Ed25519.G().ToMontgomery())
+	require.ErrorIs(t, err, ellipticcurve.ErrPointsNotOnSameCurve)
+}
+
+// encodeEdwards returns the encoding of p from section 5.1.2 of RFC 8032: the
+// little-endian Y coordinate with the lowest bit of X in the top bit.
+func encodeEdwards(p *ellipticcurve.EdwardsPoint) []byte {
+	b := p.Y().LittleEndianBytes()
+	b[31] |= byte(p.X().Num.Bit(0) << 7)
+
+	return b
+}
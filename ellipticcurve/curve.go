@@ -1,6 +1,7 @@
 package ellipticcurve
 
 import (
+	"errors"
 	"github.com/ellemouton/schnorr/finitefield"
 	"math/big"
 )
@@ -10,22 +11,138 @@ var (
 	three = big.NewInt(3)
 )
 
+var (
+	// ErrSingularCurve is returned when the coefficients given to NewCurve
+	// describe a singular curve, one with a zero discriminant, which is
+	// not an elliptic curve.
+	ErrSingularCurve = errors.New("curve is singular: 4a^3 + 27b^2 = 0")
+
+	// ErrInvalidGenerator is returned when the generator given to
+	// WithGenerator is not a point of the given order on the curve.
+	ErrInvalidGenerator = errors.New("invalid generator")
+)
+
 // Curve is an elliptic curve. It is a curve that satisfies the equation:
 //
 //	y^2 = x^3 + ax + b
 //
 // Thus it is parameterised by its coefficients A and B.
+//
+// A Curve that is used for cryptography also has a generator G that generates
+// a subgroup of prime order N, and a cofactor H such that the curve has N*H
+// points. These are optional. They are only set on the Curves returned by
+// WithGenerator and can't be changed afterwards, so a Curve can be shared by
+// any number of Points.
 type Curve struct {
+	// A and B are the coefficients of the curve. They are shared with the
+	// Curves returned by WithGenerator and are used by every Point on the
+	// Curve, so they must not be modified.
 	A *finitefield.Element
 	B *finitefield.Element
+
+	// g is the generator of the subgroup of order n.
+	g *Point
+
+	// n is the order of g.
+	n *big.Int
+
+	// h is the cofactor, the number of points on the curve divided by n.
+	h *big.Int
 }
 
-// NewCurve constructs a new Curve.
-func NewCurve(a, b *finitefield.Element) *Curve {
+// NewCurve constructs a new Curve. ErrSingularCurve is returned if the curve
+// is singular. The Curve refers to a and b themselves so they must not be
+// modified afterwards.
+func NewCurve(a, b *finitefield.Element) (*Curve, error) {
+	if a.P.Cmp(b.P) != 0 {
+		return nil, finitefield.ErrElementsOfDifferentFields
+	}
+
+	// The curve is singular if and only if 4a^3 + 27b^2 = 0.
+	var d, t finitefield.Element
+	d.SetSquare(a)
+	d.SetMul(&d, a)
+	d.SetMul(&d, finiteConst(a, 4))
+	t.SetSquare(b)
+	t.SetMul(&t, finiteConst(a, 27))
+	d.SetAdd(&d, &t)
+
+	if d.IsZero() {
+		return nil, ErrSingularCurve
+	}
+
 	return &Curve{
 		A: a,
 		B: b,
+	}, nil
+}
+
+// WithGenerator returns a copy of the Curve whose generator G is the point
+// (x, y), with order n and cofactor h. ErrPointNotOnCurve is returned if the
+// point is not on the curve and ErrInvalidGenerator if n*G is not the point at
+// infinity.
+func (c *Curve) WithGenerator(x, y *finitefield.Element, n,
+	h *big.Int) (*Curve, error) {
+
+	if n.Cmp(two) < 0 || h.Sign() <= 0 {
+		return nil, ErrInvalidGenerator
+	}
+
+	res := &Curve{
+		A: c.A,
+		B: c.B,
+	}
+
+	g, err := NewPoint(x, y, res)
+	if err != nil {
+		return nil, err
+	}
+
+	if !new(Point).SetMulWNAF(g, n, DefaultWindow).IsInfinity {
+		return nil, ErrInvalidGenerator
 	}
+
+	res.g = g
+	res.n = new(big.Int).Set(n)
+	res.h = new(big.Int).Set(h)
+
+	return res, nil
+}
+
+// G returns the generator of the Curve or nil if it does not have one. The
+// Point is shared by all the users of the Curve and must not be modified.
+func (c *Curve) G() *Point {
+	return c.g
+}
+
+// N returns a copy of the order of the generator of the Curve or nil if it
+// does not have one.
+func (c *Curve) N() *big.Int {
+	if c.n == nil {
+		return nil
+	}
+
+	return new(big.Int).Set(c.n)
+}
+
+// H returns a copy of the cofactor of the Curve or nil if it does not have a
+// generator.
+func (c *Curve) H() *big.Int {
+	if c.h == nil {
+		return nil
+	}
+
+	return new(big.Int).Set(c.h)
+}
+
+// Order returns the number of points on the curve, N*H, or nil if the Curve
+// does not have a generator.
+func (c *Curve) Order() *big.Int {
+	if c.n == nil {
+		return nil
+	}
+
+	return new(big.Int).Mul(c.n, c.h)
 }
 
 // Contains returns true if the curve contains the given coordinates.
@@ -51,7 +168,29 @@ func (c *Curve) Contains(x, y *finitefield.Element) bool {
 	return t1.Equal(t5)
 }
 
+// rhs returns x^3 + ax + b, the right hand side of the curve equation, which
+// is the square of the Y coordinates of the points with the given X
+// coordinate.
+func (c *Curve) rhs(x *finitefield.Element) *finitefield.Element {
+	var r, t finitefield.Element
+	r.SetSquare(x)
+	r.SetMul(&r, x)
+	t.SetMul(c.A, x)
+	r.SetAdd(&r, &t)
+	r.SetAdd(&r, c.B)
+
+	return &r
+}
+
 // Equal returns true if the two Curves are the same.
 func (c *Curve) Equal(o *Curve) bool {
 	return c.A.Equal(o.A) && c.B.Equal(o.B)
 }
+
+// finiteConst returns the small constant v as an Element in the same field as
+// e.
+func finiteConst(e *finitefield.Element, v int64) *finitefield.Element {
+	n := big.NewInt(v)
+
+	return &finitefield.Element{Num: n.Mod(n, e.P), P: e.P}
+}
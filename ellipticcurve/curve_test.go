@@ -0,0 +1,85 @@
+package ellipticcurve
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ellemouton/schnorr/finitefield"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewCurveSingular checks that singular curves are rejected.
+func TestNewCurveSingular(t *testing.T) {
+	f, err := finitefield.NewField(big.NewInt(223))
+	require.NoError(t, err)
+
+	// y^2 = x^3 has a cusp and y^2 = x^3 - 3x + 2 has a node.
+	_, err = NewCurve(f.Zero(), f.Zero())
+	require.ErrorIs(t, err, ErrSingularCurve)
+
+	_, err = NewCurve(f.FromInt(-3), f.FromInt(2))
+	require.ErrorIs(t, err, ErrSingularCurve)
+
+	_, err = NewCurve(f.Zero(), f.FromInt(7))
+	require.NoError(t, err)
+
+	// The coefficients must be in the same field.
+	g, err := finitefield.NewField(big.NewInt(227))
+	require.NoError(t, err)
+
+	_, err = NewCurve(f.Zero(), g.FromInt(7))
+	require.ErrorIs(t, err, finitefield.ErrElementsOfDifferentFields)
+}
+
+// TestWithGenerator checks the validation of the generator, that it does not
+// modify the original curve and that ScalarMult reduces scalars by the order of
+// the curve.
+func TestWithGenerator(t *testing.T) {
+	f, err := finitefield.NewField(big.NewInt(223))
+	require.NoError(t, err)
+
+	// y^2 = x^3 + 7 over F_223 has 252 = 7*36 points and (15, 137)
+	// generates the subgroup of order 7.
+	base, err := NewCurve(f.Zero(), f.FromInt(7))
+	require.NoError(t, err)
+	require.Nil(t, base.Order())
+
+	x, y := f.FromInt(15), f.FromInt(137)
+
+	_, err = base.WithGenerator(f.FromInt(15), f.FromInt(138),
+		big.NewInt(7), big.NewInt(36))
+	require.ErrorIs(t, err, ErrPointNotOnCurve)
+
+	_, err = base.WithGenerator(x, y, big.NewInt(5), big.NewInt(36))
+	require.ErrorIs(t, err, ErrInvalidGenerator)
+
+	curve, err := base.WithGenerator(x, y, big.NewInt(7), big.NewInt(36))
+	require.NoError(t, err)
+	require.Zero(t, big.NewInt(252).Cmp(curve.Order()))
+	require.Same(t, curve, curve.G().Curve)
+	require.True(t, curve.Equal(base))
+	require.Nil(t, base.G())
+	require.Nil(t, base.N())
+
+	// The order and cofactor are copies.
+	curve.N().SetInt64(5)
+	curve.H().SetInt64(5)
+	require.Zero(t, big.NewInt(7).Cmp(curve.N()))
+	require.Zero(t, big.NewInt(36).Cmp(curve.H()))
+
+	// Scalars are reduced by the order of the curve, which is a multiple
+	// of the order of every point, and may be negative.
+	p, err := NewPoint(f.FromInt(47), f.FromInt(71), curve)
+	require.NoError(t, err)
+
+	require.True(t, p.Equal(p.ScalarMult(big.NewInt(253))))
+	require.True(t, p.ScalarMult(big.NewInt(252)).IsInfinity)
+	require.True(t, negate(t, p).Equal(p.ScalarMult(big.NewInt(-1))))
+
+	g := curve.G()
+	for c := int64(-10); c <= 10; c++ {
+		exp, err := g.MulWNAF(big.NewInt(c), DefaultWindow)
+		require.NoError(t, err)
+		require.True(t, exp.Equal(g.ScalarMult(big.NewInt(c))))
+	}
+}
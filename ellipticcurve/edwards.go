@@ -0,0 +1,385 @@
+package ellipticcurve
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ellemouton/schnorr/finitefield"
+)
+
+// ErrIncompleteCurve is returned when the coefficients given to NewEdwardsCurve
+// do not give complete addition formulas.
+var ErrIncompleteCurve = errors.New("twisted Edwards curve needs a square a " +
+	"and a non-square d for complete addition")
+
+// EdwardsCurve is an elliptic curve in twisted Edwards form. It is a curve that
+// satisfies the equation:
+//
+//	ax^2 + y^2 = 1 + dx^2y^2
+//
+// Unlike the short Weierstrass and Montgomery forms, there is no point at
+// infinity. The identity is the point (0, 1) and, as long as a is a square and
+// d is not, a single addition formula works for every pair of points,
+// including doubling and the identity. NewEdwardsCurve only accepts curves for
+// which this holds.
+//
+// An EdwardsCurve that is used for cryptography also has a generator G that
+// generates a subgroup of prime order N, and a cofactor H such that the curve
+// has N*H points. These are only set on the EdwardsCurves returned by
+// WithGenerator and can't be changed afterwards.
+type EdwardsCurve struct {
+	A *finitefield.Element
+	D *finitefield.Element
+
+	// g is the generator of the subgroup of order n.
+	g *EdwardsPoint
+
+	// n is the order of g.
+	n *big.Int
+
+	// h is the cofactor, the number of points on the curve divided by n.
+	h *big.Int
+
+	// montgomery is the equivalent Montgomery curve.
+	montgomery *MontgomeryCurve
+}
+
+// EdwardsPoint is a point on an EdwardsCurve in affine coordinates.
+type EdwardsPoint struct {
+	x, y *finitefield.Element
+
+	*EdwardsCurve
+}
+
+// NewEdwardsCurve constructs a new EdwardsCurve. ErrSingularCurve is returned
+// if a = d or either of them is zero, and ErrIncompleteCurve is returned if a
+// is not a square or d is a square.
+func NewEdwardsCurve(a, d *finitefield.Element) (*EdwardsCurve, error) {
+	if a.P.Cmp(d.P) != 0 {
+		return nil, finitefield.ErrElementsOfDifferentFields
+	}
+
+	if a.IsZero() || d.IsZero() || a.Equal(d) {
+		return nil, ErrSingularCurve
+	}
+
+	if !a.IsSquare() || d.IsSquare() {
+		return nil, ErrIncompleteCurve
+	}
+
+	// The Montgomery curve with A = 2(a + d) / (a - d) and
+	// B = 4 / (a - d).
+	var ma, mb, t finitefield.Element
+	t.SetSub(a, d)
+	ma.SetAdd(a, d)
+	ma.SetMul(&ma, finiteConst(a, 2))
+	ma.SetDiv(&ma, &t)
+	mb.SetDiv(finiteConst(a, 4), &t)
+
+	montgomery, err := NewMontgomeryCurve(&ma, &mb)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EdwardsCurve{
+		A:          a,
+		D:          d,
+		montgomery: montgomery,
+	}, nil
+}
+
+// WithGenerator returns a copy of the EdwardsCurve whose generator G is the
+// point (x, y), with order n and cofactor h. ErrPointNotOnCurve is returned if
+// the point is not on the curve and ErrInvalidGenerator if n*G is not the
+// identity.
+func (c *EdwardsCurve) WithGenerator(x, y *finitefield.Element, n,
+	h *big.Int) (*EdwardsCurve, error) {
+
+	if n.Cmp(two) < 0 || h.Sign() <= 0 {
+		return nil, ErrInvalidGenerator
+	}
+
+	res := &EdwardsCurve{
+		A:          c.A,
+		D:          c.D,
+		montgomery: c.montgomery,
+	}
+
+	g, err := NewEdwardsPoint(x, y, res)
+	if err != nil {
+		return nil, err
+	}
+
+	if !g.ScalarMult(n).IsIdentity() {
+		return nil, ErrInvalidGenerator
+	}
+
+	res.g = g
+	res.n = new(big.Int).Set(n)
+	res.h = new(big.Int).Set(h)
+
+	return res, nil
+}
+
+// G returns the generator of the EdwardsCurve or nil if it does not have one.
+// The EdwardsPoint is shared by all the users of the curve and must not be
+// modified.
+func (c *EdwardsCurve) G() *EdwardsPoint {
+	return c.g
+}
+
+// N returns a copy of the order of the generator of the EdwardsCurve or nil if
+// it does not have one.
+func (c *EdwardsCurve) N() *big.Int {
+	if c.n == nil {
+		return nil
+	}
+
+	return new(big.Int).Set(c.n)
+}
+
+// H returns a copy of the cofactor of the EdwardsCurve or nil if it does not
+// have a generator.
+func (c *EdwardsCurve) H() *big.Int {
+	if c.h == nil {
+		return nil
+	}
+
+	return new(big.Int).Set(c.h)
+}
+
+// Contains returns true if the curve contains the given coordinates.
+func (c *EdwardsCurve) Contains(x, y *finitefield.Element) bool {
+	var xx, yy, lhs, rhs finitefield.Element
+	xx.SetSquare(x)
+	yy.SetSquare(y)
+
+	lhs.SetMul(c.A, &xx)
+	lhs.SetAdd(&lhs, &yy)
+
+	rhs.SetMul(c.D, &xx)
+	rhs.SetMul(&rhs, &yy)
+	rhs.SetAdd(&rhs, finiteConst(x, 1))
+
+	return lhs.Equal(&rhs)
+}
+
+// Equal returns true if the two EdwardsCurves are the same.
+func (c *EdwardsCurve) Equal(o *EdwardsCurve) bool {
+	return c.A.Equal(o.A) && c.D.Equal(o.D)
+}
+
+// Montgomery returns the MontgomeryCurve that the EdwardsCurve is birationally
+// equivalent to. See EdwardsPoint.ToMontgomery.
+func (c *EdwardsCurve) Montgomery() *MontgomeryCurve {
+	return c.montgomery
+}
+
+// NewEdwardsPoint constructs a new EdwardsPoint. ErrPointNotOnCurve is
+// returned if the coordinates are not on the curve.
+func NewEdwardsPoint(x, y *finitefield.Element,
+	curve *EdwardsCurve) (*EdwardsPoint, error) {
+
+	if !curve.Contains(x, y) {
+		return nil, ErrPointNotOnCurve
+	}
+
+	return &EdwardsPoint{
+		x:            new(finitefield.Element).Set(x),
+		y:            new(finitefield.Element).Set(y),
+		EdwardsCurve: curve,
+	}, nil
+}
+
+// NewEdwardsIdentity constructs the identity (0, 1) of the curve.
+func NewEdwardsIdentity(curve *EdwardsCurve) *EdwardsPoint {
+	return &EdwardsPoint{
+		x:            finiteConst(curve.A, 0),
+		y:            finiteConst(curve.A, 1),
+		EdwardsCurve: curve,
+	}
+}
+
+// X returns the X coordinate of the EdwardsPoint. The returned Element must not
+// be modified.
+func (p *EdwardsPoint) X() *finitefield.Element {
+	return p.x
+}
+
+// Y returns the Y coordinate of the EdwardsPoint. The returned Element must not
+// be modified.
+func (p *EdwardsPoint) Y() *finitefield.Element {
+	return p.y
+}
+
+// String returns the coordinates of the EdwardsPoint as "(x, y)".
+func (p *EdwardsPoint) String() string {
+	return fmt.Sprintf("(%v, %v)", p.x.Num, p.y.Num)
+}
+
+// IsIdentity returns true if the EdwardsPoint is the identity (0, 1).
+func (p *EdwardsPoint) IsIdentity() bool {
+	return p.x.IsZero() && p.y.Equal(finiteConst(p.y, 1))
+}
+
+// Equal returns true if the EdwardsPoints are the same point on the same
+// curve.
+func (p *EdwardsPoint) Equal(o *EdwardsPoint) bool {
+	return p.EdwardsCurve.Equal(o.EdwardsCurve) && p.x.Equal(o.x) &&
+		p.y.Equal(o.y)
+}
+
+// Add adds the two points together with the complete addition formula
+//
+//	x3 = (x1*y2 + y1*x2) / (1 + d*x1*x2*y1*y2)
+//	y3 = (y1*y2 - a*x1*x2) / (1 - d*x1*x2*y1*y2)
+//
+// whose denominators are never zero on the curves accepted by NewEdwardsCurve.
+func (p *EdwardsPoint) Add(o *EdwardsPoint) (*EdwardsPoint, error) {
+	if !p.EdwardsCurve.Equal(o.EdwardsCurve) {
+		return nil, ErrPointsNotOnSameCurve
+	}
+
+	return p.add(o), nil
+}
+
+// ScalarMult returns c*p for any integer c. If the order of the curve is known
+// then c is reduced modulo it first.
+//
+// NOTE: the time taken depends on the value of c so this must only be used
+// for public scalars.
+func (p *EdwardsPoint) ScalarMult(c *big.Int) *EdwardsPoint {
+	k := new(big.Int).Set(c)
+	if p.n != nil {
+		k.Mod(k, new(big.Int).Mul(p.n, p.h))
+	}
+
+	base := p
+	if k.Sign() < 0 {
+		k.Neg(k)
+		base = p.Neg()
+	}
+
+	r := NewEdwardsIdentity(p.EdwardsCurve)
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		r = r.add(r)
+		if k.Bit(i) == 1 {
+			r = r.add(base)
+		}
+	}
+
+	return r
+}
+
+// Neg returns -p, which is (-x, y).
+func (p *EdwardsPoint) Neg() *EdwardsPoint {
+	return &EdwardsPoint{
+		x:            p.x.Negate(),
+		y:            new(finitefield.Element).Set(p.y),
+		EdwardsCurve: p.EdwardsCurve,
+	}
+}
+
+// ToMontgomery maps the EdwardsPoint to the equivalent MontgomeryPoint on the
+// curve returned by Montgomery with
+//
+//	(x, y) -> ((1 + y) / (1 - y), (1 + y) / ((1 - y) * x))
+//
+// The identity is mapped to the point at infinity and (0, -1) to (0, 0). The
+// map is a group isomorphism.
+func (p *EdwardsPoint) ToMontgomery() *MontgomeryPoint {
+	m := p.montgomery
+	if p.IsIdentity() {
+		return NewMontgomeryInfinityPoint(m)
+	}
+
+	if p.x.IsZero() {
+		// This is (0, -1), the point of order 2.
+		return &MontgomeryPoint{
+			x:               finiteConst(p.x, 0),
+			y:               finiteConst(p.x, 0),
+			MontgomeryCurve: m,
+		}
+	}
+
+	var u, v, t finitefield.Element
+
+	// u = (1 + y) / (1 - y) and v = u / x
+	u.SetAdd(finiteConst(p.y, 1), p.y)
+	t.SetSub(finiteConst(p.y, 1), p.y)
+	u.SetDiv(&u, &t)
+	v.SetDiv(&u, p.x)
+
+	return &MontgomeryPoint{x: &u, y: &v, MontgomeryCurve: m}
+}
+
+// FromMontgomery maps a MontgomeryPoint on the curve returned by Montgomery
+// back to the equivalent EdwardsPoint with
+//
+//	(u, v) -> (u / v, (u - 1) / (u + 1))
+//
+// ErrPointsNotOnSameCurve is returned if the MontgomeryPoint is on a different
+// curve.
+func (c *EdwardsCurve) FromMontgomery(p *MontgomeryPoint) (*EdwardsPoint,
+	error) {
+
+	if !c.montgomery.Equal(p.MontgomeryCurve) {
+		return nil, ErrPointsNotOnSameCurve
+	}
+
+	if p.IsInfinity {
+		return NewEdwardsIdentity(c), nil
+	}
+
+	if p.y.IsZero() {
+		// The only point with v = 0 is (0, 0), the point of order 2,
+		// since the others are not defined over the field for a
+		// complete curve.
+		return &EdwardsPoint{
+			x:            finiteConst(p.x, 0),
+			y:            finiteConst(p.x, -1),
+			EdwardsCurve: c,
+		}, nil
+	}
+
+	var x, y, t finitefield.Element
+
+	x.SetDiv(p.x, p.y)
+	y.SetSub(p.x, finiteConst(p.x, 1))
+	t.SetAdd(p.x, finiteConst(p.x, 1))
+	y.SetDiv(&y, &t)
+
+	return &EdwardsPoint{x: &x, y: &y, EdwardsCurve: c}, nil
+}
+
+// ToWeierstrass maps the EdwardsPoint to the equivalent Point on the short
+// Weierstrass curve returned by Montgomery().Weierstrass(), going through the
+// Montgomery form.
+func (p *EdwardsPoint) ToWeierstrass() *Point {
+	return p.ToMontgomery().ToWeierstrass()
+}
+
+// add returns p + o using the complete addition formula. See Add.
+func (p *EdwardsPoint) add(o *EdwardsPoint) *EdwardsPoint {
+	var x1x2, y1y2, dxy, x, y, t finitefield.Element
+	x1x2.SetMul(p.x, o.x)
+	y1y2.SetMul(p.y, o.y)
+	dxy.SetMul(&x1x2, &y1y2)
+	dxy.SetMul(&dxy, p.D)
+
+	// x3 = (x1*y2 + y1*x2) / (1 + dxy)
+	x.SetMul(p.x, o.y)
+	t.SetMul(p.y, o.x)
+	x.SetAdd(&x, &t)
+	t.SetAdd(finiteConst(p.x, 1), &dxy)
+	x.SetDiv(&x, &t)
+
+	// y3 = (y1*y2 - a*x1*x2) / (1 - dxy)
+	t.SetMul(p.A, &x1x2)
+	y.SetSub(&y1y2, &t)
+	t.SetSub(finiteConst(p.x, 1), &dxy)
+	y.SetDiv(&y, &t)
+
+	return &EdwardsPoint{x: &x, y: &y, EdwardsCurve: p.EdwardsCurve}
+}
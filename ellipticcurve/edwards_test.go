@@ -0,0 +1,127 @@
+package ellipticcurve
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ellemouton/schnorr/finitefield"
+	"github.com/stretchr/testify/require"
+)
+
+// edwardsPoints returns all the points of the twisted Edwards curve
+// ax^2 + y^2 = 1 + dx^2y^2 over F_p.
+func edwardsPoints(t *testing.T, a, d, p int64) []*EdwardsPoint {
+	f, err := finitefield.NewField(big.NewInt(p))
+	require.NoError(t, err)
+
+	c, err := NewEdwardsCurve(f.FromInt(a), f.FromInt(d))
+	require.NoError(t, err)
+
+	var points []*EdwardsPoint
+	for x := int64(0); x < p; x++ {
+		for y := int64(0); y < p; y++ {
+			point, err := NewEdwardsPoint(f.FromInt(x), f.FromInt(y), c)
+			if err == nil {
+				points = append(points, point)
+			}
+		}
+	}
+
+	return points
+}
+
+// TestNewEdwardsCurve checks that singular and incomplete curves are rejected.
+func TestNewEdwardsCurve(t *testing.T) {
+	f, err := finitefield.NewField(big.NewInt(29))
+	require.NoError(t, err)
+
+	_, err = NewEdwardsCurve(f.FromInt(4), f.FromInt(4))
+	require.ErrorIs(t, err, ErrSingularCurve)
+
+	_, err = NewEdwardsCurve(f.FromInt(0), f.FromInt(2))
+	require.ErrorIs(t, err, ErrSingularCurve)
+
+	// 2 is not a square modulo 29 and 5 = 11^2 is.
+	_, err = NewEdwardsCurve(f.FromInt(2), f.FromInt(3))
+	require.ErrorIs(t, err, ErrIncompleteCurve)
+
+	_, err = NewEdwardsCurve(f.FromInt(4), f.FromInt(5))
+	require.ErrorIs(t, err, ErrIncompleteCurve)
+}
+
+// TestEdwardsAdd checks the complete addition formula against the Weierstrass
+// arithmetic for every pair of points of a small curve.
+func TestEdwardsAdd(t *testing.T) {
+	points := edwardsPoints(t, 4, 2, 29)
+	c := points[0].EdwardsCurve
+	id := NewEdwardsIdentity(c)
+
+	// Every point but the identity and (0, -1) is mapped to a point on
+	// the Montgomery curve, which has the same number of points.
+	m := c.Montgomery()
+	montgomery := montgomeryPoints(
+		t, m.A.Num.Int64(), m.B.Num.Int64(), 29,
+	)
+	require.Len(t, montgomery, len(points)-1)
+
+	for _, p := range points {
+		require.True(t, p.Equal(id.add(p)))
+		require.True(t, p.add(p.Neg()).IsIdentity())
+
+		mp := p.ToMontgomery()
+		require.Equal(t, p.IsIdentity(), mp.IsInfinity)
+		if !mp.IsInfinity {
+			require.True(t, m.Contains(mp.X(), mp.Y()))
+		}
+
+		back, err := c.FromMontgomery(mp)
+		require.NoError(t, err)
+		require.True(t, p.Equal(back))
+
+		for _, q := range points {
+			sum, err := p.Add(q)
+			require.NoError(t, err)
+			require.True(t, c.Contains(sum.X(), sum.Y()))
+
+			want, err := p.ToWeierstrass().Add(q.ToWeierstrass())
+			require.NoError(t, err)
+			require.True(t, want.Equal(sum.ToWeierstrass()))
+		}
+	}
+
+	// ScalarMult agrees with repeated addition.
+	p := points[len(points)-1]
+	r := NewEdwardsIdentity(c)
+	for k := int64(0); k < 40; k++ {
+		require.True(t, r.Equal(p.ScalarMult(big.NewInt(k))))
+		require.True(t, r.Neg().Equal(p.ScalarMult(big.NewInt(-k))))
+
+		r = r.add(p)
+	}
+}
+
+// TestEdwardsWithGenerator checks that a generator must have the given order.
+func TestEdwardsWithGenerator(t *testing.T) {
+	points := edwardsPoints(t, 4, 2, 29)
+	c := points[0].EdwardsCurve
+	n := int64(len(points))
+
+	// The order of every point divides the number of points.
+	for _, p := range points {
+		if p.IsIdentity() {
+			continue
+		}
+
+		withG, err := c.WithGenerator(
+			p.X(), p.Y(), big.NewInt(n), big.NewInt(1),
+		)
+		require.NoError(t, err)
+		require.True(t, withG.G().Equal(p))
+		require.Same(t, withG, withG.G().EdwardsCurve)
+	}
+	require.Nil(t, c.G())
+
+	g := points[1]
+	_, err := c.WithGenerator(g.X(), g.Y(), big.NewInt(n+1), big.NewInt(1))
+	require.ErrorIs(t, err, ErrInvalidGenerator)
+}
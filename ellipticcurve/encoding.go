@@ -0,0 +1,279 @@
+package ellipticcurve
+
+import (
+	"encoding"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ellemouton/schnorr/finitefield"
+)
+
+// The tags are the first byte of the SEC1 encodings of a point, as described
+// in sections 2.3.3 and 2.3.4 of "SEC 1: Elliptic Curve Cryptography"
+// (https://www.secg.org/sec1-v2.pdf). The tags of the compressed and hybrid
+// encodings also hold the parity of the Y coordinate in their lowest bit.
+const (
+	// infinityTag is the encoding of the point at infinity.
+	infinityTag = 0x00
+
+	// compressedTag is the first byte of the compressed encoding of a
+	// point with an even Y coordinate. It is followed by the X
+	// coordinate.
+	compressedTag = 0x02
+
+	// uncompressedTag is the first byte of the uncompressed encoding of a
+	// point. It is followed by the X and Y coordinates.
+	uncompressedTag = 0x04
+
+	// hybridTag is the first byte of the hybrid encoding of a point with
+	// an even Y coordinate. Like the uncompressed encoding, it is followed
+	// by the X and Y coordinates.
+	hybridTag = 0x06
+)
+
+// PointFormat is one of the SEC1 formats for encoding a Point.
+type PointFormat uint8
+
+const (
+	// FormatUncompressed encodes a Point as the byte 0x04 followed by its
+	// X and Y coordinates.
+	FormatUncompressed PointFormat = iota
+
+	// FormatCompressed encodes a Point as the byte 0x02 or 0x03, for an
+	// even or odd Y coordinate, followed by its X coordinate.
+	FormatCompressed
+
+	// FormatHybrid encodes a Point as the byte 0x06 or 0x07, for an even
+	// or odd Y coordinate, followed by its X and Y coordinates.
+	FormatHybrid
+)
+
+var (
+	// ErrInvalidPointEncoding is returned when an encoded Point can not be
+	// decoded.
+	ErrInvalidPointEncoding = errors.New("invalid point encoding")
+
+	// ErrNoCurve is returned when decoding into a Point that does not yet
+	// know which curve it is on.
+	ErrNoCurve = errors.New("the curve of the point must be set before " +
+		"decoding into it")
+)
+
+// Compile time checks to ensure that Point implements the encoding
+// interfaces.
+var (
+	_ encoding.BinaryMarshaler   = (*Point)(nil)
+	_ encoding.BinaryUnmarshaler = (*Point)(nil)
+	_ encoding.TextMarshaler     = (*Point)(nil)
+	_ encoding.TextUnmarshaler   = (*Point)(nil)
+	_ json.Marshaler             = (*Point)(nil)
+	_ json.Unmarshaler           = (*Point)(nil)
+)
+
+// Encode returns the SEC1 encoding of the Point in the given format. The point
+// at infinity is encoded as the single byte 0x00 in every format. Coordinates
+// are encoded as fixed-width big-endian integers.
+func (p *Point) Encode(format PointFormat) []byte {
+	if p.IsInfinity {
+		return []byte{infinityTag}
+	}
+
+	x, y := p.X(), p.Y()
+	parity := byte(y.Num.Bit(0))
+
+	switch format {
+	case FormatCompressed:
+		b := make([]byte, 0, 1+x.ByteLen())
+		b = append(b, compressedTag|parity)
+
+		return append(b, x.Bytes()...)
+
+	case FormatUncompressed, FormatHybrid:
+		tag := byte(uncompressedTag)
+		if format == FormatHybrid {
+			tag = hybridTag | parity
+		}
+
+		b := make([]byte, 0, 1+2*x.ByteLen())
+		b = append(b, tag)
+		b = append(b, x.Bytes()...)
+
+		return append(b, y.Bytes()...)
+
+	default:
+		panic(fmt.Sprintf("unknown point format %d", format))
+	}
+}
+
+// ParsePoint decodes a Point on the given curve from any of its SEC1
+// encodings: compressed, uncompressed, hybrid or the encoding of the point at
+// infinity. The coordinates must be canonical and must be on the curve, and
+// the parity held by the tag of a hybrid encoding must match the Y coordinate.
+//
+// Decoding a compressed point needs a square root, so the order of the field
+// of the curve must be an odd prime.
+func ParsePoint(b []byte, c *Curve) (*Point, error) {
+	if len(b) == 1 && b[0] == infinityTag {
+		return NewInfinityPoint(c), nil
+	}
+
+	if len(b) == 0 {
+		return nil, fmt.Errorf("%w: empty encoding",
+			ErrInvalidPointEncoding)
+	}
+
+	byteLen := c.A.ByteLen()
+	tag := b[0]
+
+	var wantLen int
+	switch tag {
+	case compressedTag, compressedTag | 1:
+		wantLen = 1 + byteLen
+
+	case uncompressedTag, hybridTag, hybridTag | 1:
+		wantLen = 1 + 2*byteLen
+
+	default:
+		return nil, fmt.Errorf("%w: unknown tag 0x%02x",
+			ErrInvalidPointEncoding, tag)
+	}
+
+	if len(b) != wantLen {
+		return nil, fmt.Errorf("%w: expected %d bytes for tag 0x%02x, "+
+			"got %d", ErrInvalidPointEncoding, wantLen, tag, len(b))
+	}
+
+	x := &finitefield.Element{P: c.A.P}
+	if err := x.UnmarshalBinary(b[1 : 1+byteLen]); err != nil {
+		return nil, err
+	}
+
+	if tag == compressedTag || tag == compressedTag|1 {
+		return liftX(x, uint(tag&1), c)
+	}
+
+	y := &finitefield.Element{P: c.A.P}
+	if err := y.UnmarshalBinary(b[1+byteLen:]); err != nil {
+		return nil, err
+	}
+
+	if tag != uncompressedTag && y.Num.Bit(0) != uint(tag&1) {
+		return nil, fmt.Errorf("%w: parity of hybrid tag 0x%02x does "+
+			"not match Y", ErrInvalidPointEncoding, tag)
+	}
+
+	return NewPoint(x, y, c)
+}
+
+// liftX returns the point on the curve with the given X coordinate whose Y
+// coordinate has the given parity. ErrPointNotOnCurve is returned if there is
+// no such point.
+func liftX(x *finitefield.Element, parity uint, c *Curve) (*Point, error) {
+	rhs := c.rhs(x)
+	if !rhs.IsSquare() {
+		return nil, ErrPointNotOnCurve
+	}
+
+	y, err := rhs.Sqrt()
+	if err != nil {
+		return nil, err
+	}
+
+	if y.Num.Bit(0) != parity {
+		// Zero is its own negation so it has no odd square root.
+		if y.IsZero() {
+			return nil, ErrPointNotOnCurve
+		}
+
+		y.SetNegate(y)
+	}
+
+	return NewPoint(x, y, c)
+}
+
+// MarshalBinary returns the uncompressed SEC1 encoding of the Point. The point
+// at infinity is encoded as the single byte 0x00. Any other point is encoded as
+// the byte 0x04 followed by the fixed-width big-endian encodings of its X and Y
+// coordinates.
+//
+// NOTE: this is part of the encoding.BinaryMarshaler interface.
+func (p *Point) MarshalBinary() ([]byte, error) {
+	return p.Encode(FormatUncompressed), nil
+}
+
+// UnmarshalBinary sets the Point to the value of any of its SEC1 encodings,
+// including the one produced by MarshalBinary. See ParsePoint. Since the
+// encoding does not include the curve, the Curve of the Point must already be
+// set, for example by starting from NewInfinityPoint.
+//
+// NOTE: this is part of the encoding.BinaryUnmarshaler interface.
+func (p *Point) UnmarshalBinary(b []byte) error {
+	if p.Curve == nil {
+		return ErrNoCurve
+	}
+
+	point, err := ParsePoint(b, p.Curve)
+	if err != nil {
+		return err
+	}
+
+	p.Set(point)
+
+	return nil
+}
+
+// MarshalText returns the hex encoding of the binary encoding of the Point.
+//
+// NOTE: this is part of the encoding.TextMarshaler interface.
+func (p *Point) MarshalText() ([]byte, error) {
+	b, err := p.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	text := make([]byte, hex.EncodedLen(len(b)))
+	hex.Encode(text, b)
+
+	return text, nil
+}
+
+// UnmarshalText sets the Point to the value of the hex encoding produced by
+// MarshalText. Like UnmarshalBinary, the Curve must already be set.
+//
+// NOTE: this is part of the encoding.TextUnmarshaler interface.
+func (p *Point) UnmarshalText(text []byte) error {
+	b := make([]byte, hex.DecodedLen(len(text)))
+	if _, err := hex.Decode(b, text); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPointEncoding, err)
+	}
+
+	return p.UnmarshalBinary(b)
+}
+
+// MarshalJSON encodes the Point as a JSON string holding the same hex encoding
+// as MarshalText.
+//
+// NOTE: this is part of the json.Marshaler interface.
+func (p *Point) MarshalJSON() ([]byte, error) {
+	text, err := p.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON sets the Point to the value of the JSON string produced by
+// MarshalJSON. Like UnmarshalBinary, the Curve must already be set.
+//
+// NOTE: this is part of the json.Unmarshaler interface.
+func (p *Point) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPointEncoding, err)
+	}
+
+	return p.UnmarshalText([]byte(text))
+}
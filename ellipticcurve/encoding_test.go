@@ -0,0 +1,205 @@
+package ellipticcurve
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestPointMarshal checks that a Point survives a round trip through each of
+// the encoding interfaces.
+func TestPointMarshal(t *testing.T) {
+	prime := int64(223)
+
+	tests := []struct {
+		name  string
+		point testPoint
+		bin   []byte
+	}{
+		{
+			name:  "point",
+			point: testPoint{a: 0, b: 7, x: 192, y: 105},
+			bin:   []byte{0x04, 192, 105},
+		},
+		{
+			name:  "infinity",
+			point: testPoint{a: 0, b: 7, infinity: true},
+			bin:   []byte{0x00},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := test.point.ToPoint(t, prime)
+
+			b, err := p.MarshalBinary()
+			require.NoError(t, err)
+			require.Equal(t, test.bin, b)
+
+			dec := NewInfinityPoint(p.Curve)
+			require.NoError(t, dec.UnmarshalBinary(b))
+			require.True(t, p.Equal(dec))
+
+			text, err := p.MarshalText()
+			require.NoError(t, err)
+
+			dec = NewInfinityPoint(p.Curve)
+			require.NoError(t, dec.UnmarshalText(text))
+			require.True(t, p.Equal(dec))
+
+			j, err := json.Marshal(p)
+			require.NoError(t, err)
+
+			dec = NewInfinityPoint(p.Curve)
+			require.NoError(t, json.Unmarshal(j, dec))
+			require.True(t, p.Equal(dec))
+		})
+	}
+}
+
+// TestPointEncode checks the SEC1 encodings of a Point in each format and that
+// ParsePoint decodes all of them.
+func TestPointEncode(t *testing.T) {
+	prime := int64(223)
+
+	tests := []struct {
+		name         string
+		point        testPoint
+		compressed   []byte
+		uncompressed []byte
+		hybrid       []byte
+	}{
+		{
+			name:         "odd y",
+			point:        testPoint{a: 0, b: 7, x: 192, y: 105},
+			compressed:   []byte{0x03, 192},
+			uncompressed: []byte{0x04, 192, 105},
+			hybrid:       []byte{0x07, 192, 105},
+		},
+		{
+			name:         "even y",
+			point:        testPoint{a: 0, b: 7, x: 17, y: 56},
+			compressed:   []byte{0x02, 17},
+			uncompressed: []byte{0x04, 17, 56},
+			hybrid:       []byte{0x06, 17, 56},
+		},
+		{
+			name:         "zero y",
+			point:        testPoint{a: 0, b: 7, x: 6, y: 0},
+			compressed:   []byte{0x02, 6},
+			uncompressed: []byte{0x04, 6, 0},
+			hybrid:       []byte{0x06, 6, 0},
+		},
+		{
+			name:         "infinity",
+			point:        testPoint{a: 0, b: 7, infinity: true},
+			compressed:   []byte{0x00},
+			uncompressed: []byte{0x00},
+			hybrid:       []byte{0x00},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := test.point.ToPoint(t, prime)
+
+			for format, want := range map[PointFormat][]byte{
+				FormatCompressed:   test.compressed,
+				FormatUncompressed: test.uncompressed,
+				FormatHybrid:       test.hybrid,
+			} {
+				b := p.Encode(format)
+				require.Equal(t, want, b)
+
+				dec, err := ParsePoint(b, p.Curve)
+				require.NoError(t, err)
+				require.True(t, p.Equal(dec))
+			}
+		})
+	}
+}
+
+// TestParsePointErrors checks that ParsePoint rejects encodings that are
+// malformed or that are not of a point on the curve.
+func TestParsePointErrors(t *testing.T) {
+	curve := (&testPoint{a: 0, b: 7, infinity: true}).ToPoint(t, 223).Curve
+
+	tests := []struct {
+		name string
+		bin  []byte
+		err  error
+	}{
+		{
+			name: "compressed too long",
+			bin:  []byte{0x02, 17, 56},
+			err:  ErrInvalidPointEncoding,
+		},
+		{
+			name: "hybrid too short",
+			bin:  []byte{0x06, 17},
+			err:  ErrInvalidPointEncoding,
+		},
+		{
+			name: "infinity too long",
+			bin:  []byte{0x00, 0x00},
+			err:  ErrInvalidPointEncoding,
+		},
+		{
+			name: "hybrid wrong parity",
+			bin:  []byte{0x07, 17, 56},
+			err:  ErrInvalidPointEncoding,
+		},
+		{
+			name: "hybrid not on curve",
+			bin:  []byte{0x06, 17, 58},
+			err:  ErrPointNotOnCurve,
+		},
+		{
+			name: "compressed not on curve",
+			bin:  []byte{0x02, 4},
+			err:  ErrPointNotOnCurve,
+		},
+		{
+			name: "compressed odd zero y",
+			bin:  []byte{0x03, 6},
+			err:  ErrPointNotOnCurve,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParsePoint(test.bin, curve)
+			require.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
+// TestPointUnmarshalErrors checks that invalid encodings are rejected.
+func TestPointUnmarshalErrors(t *testing.T) {
+	curve := (&testPoint{a: 0, b: 7, infinity: true}).ToPoint(t, 223).Curve
+
+	tests := []struct {
+		name string
+		bin  []byte
+	}{
+		{name: "empty", bin: []byte{}},
+		{name: "bad tag", bin: []byte{0x05, 192, 105}},
+		{name: "too short", bin: []byte{0x04, 192}},
+		{name: "non-canonical", bin: []byte{0x04, 223, 105}},
+		{name: "not on curve", bin: []byte{0x04, 192, 106}},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			err := NewInfinityPoint(curve).UnmarshalBinary(test.bin)
+			require.Error(t, err)
+		})
+	}
+
+	require.ErrorIs(t, new(Point).UnmarshalBinary([]byte{0}), ErrNoCurve)
+}
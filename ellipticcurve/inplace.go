@@ -0,0 +1,287 @@
+package ellipticcurve
+
+import (
+	"math/big"
+
+	"github.com/ellemouton/schnorr/finitefield"
+)
+
+// The methods in this file are in-place variants of the Point arithmetic in the
+// style of math/big: z.SetAdd(p, q) sets z to p + q and returns z. The result
+// is written into the existing Jacobian coordinates of z, and the affine
+// coordinates previously returned by z.X and z.Y are left untouched.
+//
+// The receiver may alias any of the operands. The operands must be on the same
+// curve and, since there is no error to return, the methods panic with
+// ErrPointsNotOnSameCurve if they are not.
+
+// Set sets z to p and returns z.
+func (z *Point) Set(p *Point) *Point {
+	if p.IsInfinity {
+		return z.setInfinity(p.Curve)
+	}
+
+	// The cached affine coordinates are never changed once stored so
+	// they can be shared. They are loaded first since z may alias p.
+	a := p.affine.Load()
+	z.setJacobian(p.Curve, &p.x, &p.y, &p.z)
+	z.affine.Store(a)
+
+	return z
+}
+
+// SetAdd sets z to p + q and returns z.
+func (z *Point) SetAdd(p, q *Point) *Point {
+	if !p.sameCurve(q) {
+		panic(ErrPointsNotOnSameCurve)
+	}
+
+	switch {
+	case p.IsInfinity:
+		return z.Set(q)
+
+	case q.IsInfinity:
+		return z.Set(p)
+	}
+
+	// If either point already knows its affine coordinates then the
+	// cheaper mixed addition is used.
+	if a := q.affine.Load(); a != nil {
+		return z.addMixed(p, a)
+	}
+	if a := p.affine.Load(); a != nil {
+		return z.addMixed(q, a)
+	}
+
+	return z.add(p, &q.x, &q.y, &q.z)
+}
+
+// SetNeg sets z to -p and returns z. The inverse of (x, y) is (x, -y).
+func (z *Point) SetNeg(p *Point) *Point {
+	if p.IsInfinity {
+		return z.setInfinity(p.Curve)
+	}
+
+	// Negating Y in Jacobian coordinates negates the affine Y, so any
+	// cached affine coordinates can be negated along with it.
+	a := p.affine.Load().negate()
+	z.setJacobian(p.Curve, &p.x, &p.y, &p.z)
+	z.y.SetNegate(&z.y)
+	z.affine.Store(a)
+
+	return z
+}
+
+// SetSub sets z to p - q and returns z.
+func (z *Point) SetSub(p, q *Point) *Point {
+	if !p.sameCurve(q) {
+		panic(ErrPointsNotOnSameCurve)
+	}
+
+	var negQ Point
+
+	return z.SetAdd(p, negQ.SetNeg(q))
+}
+
+// SetDouble sets z to p + p and returns z.
+func (z *Point) SetDouble(p *Point) *Point {
+	// A point with a zero Y is its own inverse.
+	if p.IsInfinity || p.y.IsZero() {
+		return z.setInfinity(p.Curve)
+	}
+
+	var xx, yy, s, m, t, x3, y3, z3 finitefield.Element
+
+	xx.SetSquare(&p.x)
+	yy.SetSquare(&p.y)
+
+	// s = 4*x*y^2
+	s.SetMul(&p.x, &yy)
+	s.SetAdd(&s, &s)
+	s.SetAdd(&s, &s)
+
+	// m = 3*x^2 + a*z^4. The second term vanishes on curves such as
+	// secp256k1 that have a = 0.
+	m.SetAdd(&xx, &xx)
+	m.SetAdd(&m, &xx)
+	if !p.A.IsZero() {
+		t.SetSquare(&p.z)
+		t.SetSquare(&t)
+		t.SetMul(&t, p.A)
+		m.SetAdd(&m, &t)
+	}
+
+	// x3 = m^2 - 2*s
+	x3.SetSquare(&m)
+	x3.SetSub(&x3, &s)
+	x3.SetSub(&x3, &s)
+
+	// y3 = m*(s - x3) - 8*y^4
+	t.SetSquare(&yy)
+	t.SetAdd(&t, &t)
+	t.SetAdd(&t, &t)
+	t.SetAdd(&t, &t)
+	y3.SetSub(&s, &x3)
+	y3.SetMul(&m, &y3)
+	y3.SetSub(&y3, &t)
+
+	// z3 = 2*y*z
+	z3.SetMul(&p.y, &p.z)
+	z3.SetAdd(&z3, &z3)
+
+	return z.setJacobian(p.Curve, &x3, &y3, &z3)
+}
+
+// SetMul sets z to c*p and returns z. The point at infinity is returned for
+// any c that is not positive.
+//
+// NOTE: this is vulnerable to the side channel leakage attack described in
+//
+//	https://link.springer.com/content/pdf/10.1007/978-3-540-28632-5_14.pdf.
+func (z *Point) SetMul(p *Point, c *big.Int) *Point {
+	var result Point
+	result.setInfinity(p.Curve)
+
+	if p.IsInfinity || c.Sign() <= 0 {
+		return z.Set(&result)
+	}
+
+	// The base point is converted to affine coordinates once so that
+	// every addition below is a mixed addition.
+	base := p.toAffine()
+	for i := c.BitLen() - 1; i >= 0; i-- {
+		result.SetDouble(&result)
+
+		if c.Bit(i) == 1 {
+			result.addMixed(&result, base)
+		}
+	}
+
+	return z.Set(&result)
+}
+
+// add sets z to p + q, where q is given by its Jacobian coordinates, and
+// returns z. Neither point may be at infinity.
+func (z *Point) add(p *Point, x2, y2, z2 *finitefield.Element) *Point {
+	var u1, s1, t finitefield.Element
+
+	// u1 = x1*z2^2 and s1 = y1*z2^3
+	t.SetSquare(z2)
+	u1.SetMul(&p.x, &t)
+	t.SetMul(&t, z2)
+	s1.SetMul(&p.y, &t)
+
+	return z.addWith(p, &u1, &s1, x2, y2, z2)
+}
+
+// addMixed sets z to p + q, where q is given by its affine coordinates, and
+// returns z. The addition saves the multiplications by the Z coordinate of q,
+// which is one. p may be at infinity.
+func (z *Point) addMixed(p *Point, q *affinePoint) *Point {
+	if p.IsInfinity {
+		return z.setAffine(p.Curve, q.x, q.y)
+	}
+
+	return z.addWith(p, &p.x, &p.y, q.x, q.y, nil)
+}
+
+// addWith finishes the addition of p and the point q with Jacobian coordinates
+// (x2, y2, z2) given u1 = x1*z2^2 and s1 = y1*z2^3. A nil z2 means that q is
+// affine.
+func (z *Point) addWith(p *Point, u1, s1, x2, y2,
+	z2 *finitefield.Element) *Point {
+
+	var u2, s2, h, r, t, x3, y3, z3 finitefield.Element
+
+	// u2 = x2*z1^2 and s2 = y2*z1^3
+	t.SetSquare(&p.z)
+	u2.SetMul(x2, &t)
+	t.SetMul(&t, &p.z)
+	s2.SetMul(y2, &t)
+
+	// h = u2 - u1 and r = s2 - s1
+	h.SetSub(&u2, u1)
+	r.SetSub(&s2, s1)
+
+	if h.IsZero() {
+		// The points are either each other's inverse or they are the
+		// same point.
+		if !r.IsZero() {
+			return z.setInfinity(p.Curve)
+		}
+
+		return z.SetDouble(p)
+	}
+
+	// z3 = z1*z2*h
+	z3.SetMul(&p.z, &h)
+	if z2 != nil {
+		z3.SetMul(&z3, z2)
+	}
+
+	// With v = u1*h^2:
+	//   x3 = r^2 - h^3 - 2*v
+	//   y3 = r*(v - x3) - s1*h^3
+	t.SetSquare(&h)
+	u2.SetMul(u1, &t)
+	t.SetMul(&t, &h)
+
+	x3.SetSquare(&r)
+	x3.SetSub(&x3, &t)
+	x3.SetSub(&x3, &u2)
+	x3.SetSub(&x3, &u2)
+
+	y3.SetSub(&u2, &x3)
+	y3.SetMul(&r, &y3)
+	t.SetMul(s1, &t)
+	y3.SetSub(&y3, &t)
+
+	return z.setJacobian(p.Curve, &x3, &y3, &z3)
+}
+
+// setJacobian sets z to the point with the given Jacobian coordinates and
+// returns z. The coordinates are copied into the storage of z, so each of them
+// may be the matching coordinate of z itself.
+func (z *Point) setJacobian(curve *Curve, x, y, zc *finitefield.Element) *Point {
+	z.x.Set(x)
+	z.y.Set(y)
+	z.z.Set(zc)
+	z.Curve = curve
+	z.IsInfinity = false
+	z.affine.Store(nil)
+
+	return z
+}
+
+// setAffine sets z to the point with the given affine coordinates and returns
+// z.
+func (z *Point) setAffine(curve *Curve, x, y *finitefield.Element) *Point {
+	a := &affinePoint{
+		x: new(finitefield.Element).Set(x),
+		y: new(finitefield.Element).Set(y),
+	}
+
+	z.x.Set(x)
+	z.y.Set(y)
+	if z.z.Num == nil {
+		z.z.Num = new(big.Int)
+	}
+	z.z.Num.SetInt64(1)
+	z.z.P = x.P
+
+	z.Curve = curve
+	z.IsInfinity = false
+	z.affine.Store(a)
+
+	return z
+}
+
+// setInfinity sets z to the point at infinity on the given curve and returns z.
+// The storage of the coordinates is kept for reuse.
+func (z *Point) setInfinity(curve *Curve) *Point {
+	z.Curve = curve
+	z.IsInfinity = true
+	z.affine.Store(nil)
+
+	return z
+}
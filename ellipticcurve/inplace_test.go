@@ -0,0 +1,106 @@
+package ellipticcurve
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestInPlaceArithmetic checks the in-place methods against the methods that
+// return a new Point, including when the receiver aliases an operand.
+func TestInPlaceArithmetic(t *testing.T) {
+	prime := int64(223)
+
+	points := []*Point{
+		(&testPoint{a: 0, b: 7, x: 192, y: 105}).ToPoint(t, prime),
+		(&testPoint{a: 0, b: 7, x: 17, y: 56}).ToPoint(t, prime),
+		(&testPoint{a: 0, b: 7, x: 47, y: 71}).ToPoint(t, prime),
+		(&testPoint{a: 0, b: 7, x: 47, y: 152}).ToPoint(t, prime),
+		(&testPoint{a: 0, b: 7, infinity: true}).ToPoint(t, prime),
+	}
+
+	for _, p := range points {
+		for _, q := range points {
+			exp, err := p.Add(q)
+			require.NoError(t, err)
+
+			z := new(Point).SetAdd(p, q)
+			require.True(t, exp.Equal(z))
+
+			z = p.Copy()
+			require.True(t, exp.Equal(z.SetAdd(z, q)))
+
+			z = q.Copy()
+			require.True(t, exp.Equal(z.SetAdd(p, z)))
+
+			// Reusing a receiver that holds a finite point for an
+			// infinite result and back again works.
+			z.SetAdd(p, q)
+			require.True(t, exp.Equal(z))
+		}
+
+		// The result of doubling in place matches adding the point to
+		// a copy of itself and c*p matches repeated addition.
+		double, err := p.Add(p.Copy())
+		require.NoError(t, err)
+
+		z := p.Copy()
+		require.True(t, double.Equal(z.SetAdd(z, z)))
+
+		sum := NewInfinityPoint(p.Curve)
+		for c := int64(0); c < 10; c++ {
+			z = p.Copy()
+			require.True(t, sum.Equal(z.SetMul(z, big.NewInt(c))))
+
+			sum.SetAdd(sum, p)
+		}
+	}
+
+	// A copy owns its coordinates so changing it in place leaves the
+	// original untouched.
+	p := points[0]
+	c := p.Copy()
+	c.SetAdd(c, points[1])
+	require.Equal(t, int64(192), p.X().Num.Int64())
+	require.Equal(t, int64(105), p.Y().Num.Int64())
+}
+
+// TestInPlaceDifferentCurves checks that the in-place methods panic if the
+// operands are on different curves.
+func TestInPlaceDifferentCurves(t *testing.T) {
+	p := (&testPoint{a: 0, b: 7, x: 192, y: 105}).ToPoint(t, 223)
+	q := (&testPoint{a: 5, b: 7, x: 2, y: 5}).ToPoint(t, 223)
+
+	require.PanicsWithValue(t, ErrPointsNotOnSameCurve, func() {
+		new(Point).SetAdd(p, q)
+	})
+	require.PanicsWithValue(t, ErrPointsNotOnSameCurve, func() {
+		new(Point).SetSub(p, q)
+	})
+
+	_, err := p.Sub(q)
+	require.ErrorIs(t, err, ErrPointsNotOnSameCurve)
+}
+
+func BenchmarkPointAdd(b *testing.B) {
+	p := (&testPoint{a: 0, b: 7, x: 192, y: 105}).ToPoint(b, 223)
+	q := (&testPoint{a: 0, b: 7, x: 17, y: 56}).ToPoint(b, 223)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p, _ = p.Add(q)
+	}
+}
+
+func BenchmarkPointSetAdd(b *testing.B) {
+	p := (&testPoint{a: 0, b: 7, x: 192, y: 105}).ToPoint(b, 223)
+	q := (&testPoint{a: 0, b: 7, x: 17, y: 56}).ToPoint(b, 223)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.SetAdd(p, q)
+	}
+}
@@ -0,0 +1,284 @@
+package ellipticcurve
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ellemouton/schnorr/finitefield"
+)
+
+// MontgomeryCurve is an elliptic curve in Montgomery form. It is a curve that
+// satisfies the equation:
+//
+//	By^2 = x^3 + Ax^2 + x
+//
+// Montgomery curves are best known for the Montgomery ladder, which computes
+// the X coordinate of a multiple of a point from the X coordinate of the point
+// alone. Every Montgomery curve is birationally equivalent to a short
+// Weierstrass Curve, and to a twisted Edwards curve.
+type MontgomeryCurve struct {
+	A *finitefield.Element
+	B *finitefield.Element
+
+	// a24 is (A-2)/4, the constant used by the ladder.
+	a24 *finitefield.Element
+
+	// weierstrass is the equivalent short Weierstrass curve.
+	weierstrass *Curve
+}
+
+// MontgomeryPoint is a point on a MontgomeryCurve in affine coordinates.
+type MontgomeryPoint struct {
+	x, y *finitefield.Element
+
+	*MontgomeryCurve
+
+	// IsInfinity is true if the point is the point at infinity, which is
+	// the identity of the group. If true, X and Y will return nil.
+	IsInfinity bool
+}
+
+// NewMontgomeryCurve constructs a new MontgomeryCurve. ErrSingularCurve is
+// returned if B = 0 or A^2 = 4, in which case the curve is not an elliptic
+// curve. The order of the field must be larger than 3.
+func NewMontgomeryCurve(a, b *finitefield.Element) (*MontgomeryCurve, error) {
+	if a.P.Cmp(b.P) != 0 {
+		return nil, finitefield.ErrElementsOfDifferentFields
+	}
+
+	var t finitefield.Element
+	t.SetSquare(a)
+	t.SetSub(&t, finiteConst(a, 4))
+	if b.IsZero() || t.IsZero() {
+		return nil, ErrSingularCurve
+	}
+
+	// The curve y^2 = x^3 + a'x + b' with
+	//   a' = (3 - A^2) / (3B^2)
+	//   b' = (2A^3 - 9A) / (27B^3)
+	// is reached by the change of variables x' = (x + A/3) / B and
+	// y' = y / B.
+	var aw, bw, b2, b3 finitefield.Element
+	b2.SetSquare(b)
+	b3.SetMul(&b2, b)
+
+	aw.SetSquare(a)
+	aw.SetSub(finiteConst(a, 3), &aw)
+	t.SetMul(&b2, finiteConst(a, 3))
+	aw.SetDiv(&aw, &t)
+
+	bw.SetSquare(a)
+	bw.SetMul(&bw, finiteConst(a, 2))
+	bw.SetSub(&bw, finiteConst(a, 9))
+	bw.SetMul(&bw, a)
+	t.SetMul(&b3, finiteConst(a, 27))
+	bw.SetDiv(&bw, &t)
+
+	weierstrass, err := NewCurve(&aw, &bw)
+	if err != nil {
+		return nil, err
+	}
+
+	a24 := new(finitefield.Element).SetSub(a, finiteConst(a, 2))
+	a24.SetDiv(a24, finiteConst(a, 4))
+
+	return &MontgomeryCurve{
+		A:           a,
+		B:           b,
+		a24:         a24,
+		weierstrass: weierstrass,
+	}, nil
+}
+
+// Contains returns true if the curve contains the given coordinates.
+func (c *MontgomeryCurve) Contains(x, y *finitefield.Element) bool {
+	lhs := new(finitefield.Element).SetSquare(y)
+	lhs.SetMul(lhs, c.B)
+
+	return lhs.Equal(c.rhs(x))
+}
+
+// Equal returns true if the two MontgomeryCurves are the same.
+func (c *MontgomeryCurve) Equal(o *MontgomeryCurve) bool {
+	return c.A.Equal(o.A) && c.B.Equal(o.B)
+}
+
+// Weierstrass returns the short Weierstrass Curve that the MontgomeryCurve is
+// birationally equivalent to. See MontgomeryPoint.ToWeierstrass.
+func (c *MontgomeryCurve) Weierstrass() *Curve {
+	return c.weierstrass
+}
+
+// Ladder returns the X coordinate of k*P where x is the X coordinate of P,
+// computed with the Montgomery ladder from section 5 of RFC 7748. The point at
+// infinity is given the X coordinate 0, as in RFC 7748. Since the X coordinate
+// of -P is the same as that of P, the sign of k is ignored.
+//
+// The ladder never needs the Y coordinate so x does not have to be the X
+// coordinate of a point on the curve. If it is not, the result is the X
+// coordinate of a multiple of a point on the quadratic twist of the curve.
+//
+// NOTE: the ladder runs the same steps for every bit of k but the big.Int
+// arithmetic does not run in constant time, so this must not be used with
+// secret scalars.
+func (c *MontgomeryCurve) Ladder(k *big.Int,
+	x *finitefield.Element) *finitefield.Element {
+
+	k = new(big.Int).Abs(k)
+
+	var (
+		x1     = x
+		x2, z2 = finiteConst(x, 1), finiteConst(x, 0)
+		x3, z3 = new(finitefield.Element).Set(x), finiteConst(x, 1)
+
+		a, aa, b, bb, e, cc, d, da, cb finitefield.Element
+	)
+
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		bit := k.Bit(i) == 1
+		if bit {
+			x2, x3 = x3, x2
+			z2, z3 = z3, z2
+		}
+
+		a.SetAdd(x2, z2)
+		aa.SetSquare(&a)
+		b.SetSub(x2, z2)
+		bb.SetSquare(&b)
+		e.SetSub(&aa, &bb)
+		cc.SetAdd(x3, z3)
+		d.SetSub(x3, z3)
+		da.SetMul(&d, &a)
+		cb.SetMul(&cc, &b)
+
+		// x3 = (DA + CB)^2 and z3 = x1 * (DA - CB)^2
+		x3.SetAdd(&da, &cb)
+		x3.SetSquare(x3)
+		z3.SetSub(&da, &cb)
+		z3.SetSquare(z3)
+		z3.SetMul(z3, x1)
+
+		// x2 = AA * BB and z2 = E * (AA + a24 * E)
+		x2.SetMul(&aa, &bb)
+		z2.SetMul(c.a24, &e)
+		z2.SetAdd(z2, &aa)
+		z2.SetMul(z2, &e)
+
+		if bit {
+			x2, x3 = x3, x2
+			z2, z3 = z3, z2
+		}
+	}
+
+	// The inverse of zero is zero so the point at infinity, with z2 = 0,
+	// gets the X coordinate 0.
+	return z2.SetInverse(z2).SetMul(z2, x2)
+}
+
+// NewMontgomeryPoint constructs a new MontgomeryPoint. ErrPointNotOnCurve is
+// returned if the coordinates are not on the curve.
+func NewMontgomeryPoint(x, y *finitefield.Element,
+	curve *MontgomeryCurve) (*MontgomeryPoint, error) {
+
+	if !curve.Contains(x, y) {
+		return nil, ErrPointNotOnCurve
+	}
+
+	return &MontgomeryPoint{
+		x:               new(finitefield.Element).Set(x),
+		y:               new(finitefield.Element).Set(y),
+		MontgomeryCurve: curve,
+	}, nil
+}
+
+// NewMontgomeryInfinityPoint constructs a new MontgomeryPoint at infinity.
+func NewMontgomeryInfinityPoint(curve *MontgomeryCurve) *MontgomeryPoint {
+	return &MontgomeryPoint{MontgomeryCurve: curve, IsInfinity: true}
+}
+
+// X returns the X coordinate of the MontgomeryPoint or nil if it is at
+// infinity. The returned Element must not be modified.
+func (p *MontgomeryPoint) X() *finitefield.Element {
+	return p.x
+}
+
+// Y returns the Y coordinate of the MontgomeryPoint or nil if it is at
+// infinity. The returned Element must not be modified.
+func (p *MontgomeryPoint) Y() *finitefield.Element {
+	return p.y
+}
+
+// String returns the coordinates of the MontgomeryPoint as "(x, y)", or "O" for
+// the point at infinity.
+func (p *MontgomeryPoint) String() string {
+	if p.IsInfinity {
+		return "O"
+	}
+
+	return fmt.Sprintf("(%v, %v)", p.x.Num, p.y.Num)
+}
+
+// Equal returns true if the MontgomeryPoints are the same point on the same
+// curve.
+func (p *MontgomeryPoint) Equal(o *MontgomeryPoint) bool {
+	if !p.MontgomeryCurve.Equal(o.MontgomeryCurve) {
+		return false
+	}
+
+	if p.IsInfinity || o.IsInfinity {
+		return p.IsInfinity && o.IsInfinity
+	}
+
+	return p.x.Equal(o.x) && p.y.Equal(o.y)
+}
+
+// ToWeierstrass maps the MontgomeryPoint to the equivalent Point on the curve
+// returned by Weierstrass. The map is a group isomorphism so it can be used to
+// do the arithmetic of a MontgomeryCurve with the Weierstrass Point.
+func (p *MontgomeryPoint) ToWeierstrass() *Point {
+	w := p.weierstrass
+	if p.IsInfinity {
+		return NewInfinityPoint(w)
+	}
+
+	// x' = (x + A/3) / B and y' = y / B
+	var x, y, t finitefield.Element
+	t.SetDiv(p.A, finiteConst(p.A, 3))
+	x.SetAdd(p.x, &t)
+	x.SetDiv(&x, p.B)
+	y.SetDiv(p.y, p.B)
+
+	return new(Point).setAffine(w, &x, &y)
+}
+
+// FromWeierstrass maps a Point on the curve returned by Weierstrass back to the
+// equivalent MontgomeryPoint. ErrPointsNotOnSameCurve is returned if the Point
+// is on a different curve.
+func (c *MontgomeryCurve) FromWeierstrass(p *Point) (*MontgomeryPoint, error) {
+	if !c.weierstrass.Equal(p.Curve) {
+		return nil, ErrPointsNotOnSameCurve
+	}
+
+	if p.IsInfinity {
+		return NewMontgomeryInfinityPoint(c), nil
+	}
+
+	// x = B*x' - A/3 and y = B*y'
+	var x, y, t finitefield.Element
+	t.SetDiv(c.A, finiteConst(c.A, 3))
+	x.SetMul(c.B, p.X())
+	x.SetSub(&x, &t)
+	y.SetMul(c.B, p.Y())
+
+	return &MontgomeryPoint{x: &x, y: &y, MontgomeryCurve: c}, nil
+}
+
+// rhs returns x^3 + Ax^2 + x, the right hand side of the curve equation.
+func (c *MontgomeryCurve) rhs(x *finitefield.Element) *finitefield.Element {
+	// x * (x * (x + A) + 1)
+	r := new(finitefield.Element).SetAdd(x, c.A)
+	r.SetMul(r, x)
+	r.SetAdd(r, finiteConst(x, 1))
+
+	return r.SetMul(r, x)
+}
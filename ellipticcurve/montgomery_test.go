@@ -0,0 +1,89 @@
+package ellipticcurve
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ellemouton/schnorr/finitefield"
+	"github.com/stretchr/testify/require"
+)
+
+// montgomeryPoints returns all the affine points of the Montgomery curve
+// By^2 = x^3 + Ax^2 + x over F_p.
+func montgomeryPoints(t *testing.T, a, b, p int64) []*MontgomeryPoint {
+	f, err := finitefield.NewField(big.NewInt(p))
+	require.NoError(t, err)
+
+	c, err := NewMontgomeryCurve(f.FromInt(a), f.FromInt(b))
+	require.NoError(t, err)
+
+	var points []*MontgomeryPoint
+	for x := int64(0); x < p; x++ {
+		for y := int64(0); y < p; y++ {
+			point, err := NewMontgomeryPoint(
+				f.FromInt(x), f.FromInt(y), c,
+			)
+			if err == nil {
+				points = append(points, point)
+			}
+		}
+	}
+
+	return points
+}
+
+// TestNewMontgomeryCurve checks that singular curves are rejected.
+func TestNewMontgomeryCurve(t *testing.T) {
+	f, err := finitefield.NewField(big.NewInt(101))
+	require.NoError(t, err)
+
+	_, err = NewMontgomeryCurve(f.FromInt(3), f.FromInt(0))
+	require.ErrorIs(t, err, ErrSingularCurve)
+
+	_, err = NewMontgomeryCurve(f.FromInt(-2), f.FromInt(1))
+	require.ErrorIs(t, err, ErrSingularCurve)
+}
+
+// TestMontgomeryLadder checks the ladder and the map to Weierstrass form
+// against each other for every point of a small curve.
+func TestMontgomeryLadder(t *testing.T) {
+	points := montgomeryPoints(t, 3, 5, 101)
+	require.NotEmpty(t, points)
+
+	c := points[0].MontgomeryCurve
+	w := c.Weierstrass()
+
+	for _, p := range points {
+		wp := p.ToWeierstrass()
+		require.True(t, w.Contains(wp.X(), wp.Y()))
+
+		back, err := c.FromWeierstrass(wp)
+		require.NoError(t, err)
+		require.True(t, p.Equal(back))
+
+		for k := int64(-3); k < 20; k++ {
+			q := wp.ScalarMult(big.NewInt(k))
+
+			// The point at infinity has the X coordinate 0.
+			want := finiteConst(p.X(), 0)
+			if !q.IsInfinity {
+				m, err := c.FromWeierstrass(q)
+				require.NoError(t, err)
+
+				want = m.X()
+			}
+
+			got := c.Ladder(big.NewInt(k), p.X())
+			require.True(t, want.Equal(got), "k=%d p=%v", k, p)
+		}
+	}
+
+	inf, err := c.FromWeierstrass(NewInfinityPoint(w))
+	require.NoError(t, err)
+	require.True(t, inf.IsInfinity)
+	require.True(t, inf.ToWeierstrass().IsInfinity)
+
+	other := toyCurve(t, 0, 7, 223)
+	_, err = c.FromWeierstrass(NewInfinityPoint(other))
+	require.ErrorIs(t, err, ErrPointsNotOnSameCurve)
+}
@@ -0,0 +1,207 @@
+package ellipticcurve
+
+import (
+	"errors"
+	"math/big"
+)
+
+// pippengerThreshold is the number of points from which MultiScalarMul
+// switches from Strauss to Pippenger.
+const pippengerThreshold = 128
+
+var (
+	// ErrLengthMismatch is returned when the number of points and scalars
+	// given to MultiScalarMul differ.
+	ErrLengthMismatch = errors.New("number of points and scalars differ")
+
+	// ErrNoPoints is returned when MultiScalarMul is not given any points.
+	ErrNoPoints = errors.New("at least one point is required")
+)
+
+// MultiScalarMul returns the sum c_0*P_0 + c_1*P_1 + ... of the given points
+// multiplied by the scalar at the same index. This is much faster than
+// multiplying each point separately and adding up the results. Scalars may be
+// negative.
+//
+// Interleaved wNAF multiplication (Strauss) is used for a small number of
+// points and the bucket method (Pippenger) for a large number of points.
+//
+// NOTE: the time taken depends on the values of the scalars so this must only
+// be used for public scalars, such as during signature verification.
+func MultiScalarMul(points []*Point, scalars []*big.Int) (*Point, error) {
+	if len(points) != len(scalars) {
+		return nil, ErrLengthMismatch
+	}
+
+	if len(points) == 0 {
+		return nil, ErrNoPoints
+	}
+
+	for _, p := range points[1:] {
+		if !p.sameCurve(points[0]) {
+			return nil, ErrPointsNotOnSameCurve
+		}
+	}
+
+	if len(points) < pippengerThreshold {
+		return strauss(points, scalars), nil
+	}
+
+	return pippenger(points, scalars), nil
+}
+
+// strauss computes the multi-scalar multiplication by walking through the wNAF
+// digits of all the scalars at once so that the doublings are shared.
+func strauss(points []*Point, scalars []*big.Int) *Point {
+	var (
+		result  = NewInfinityPoint(points[0].Curve)
+		digits  = make([][]int8, 0, len(points))
+		nonZero = make([]*Point, 0, len(points))
+		maxLen  int
+	)
+	for i, p := range points {
+		if p.IsInfinity || scalars[i].Sign() == 0 {
+			continue
+		}
+
+		d := wnaf(scalars[i], DefaultWindow)
+		if len(d) > maxLen {
+			maxLen = len(d)
+		}
+
+		digits = append(digits, d)
+		nonZero = append(nonZero, p)
+	}
+
+	if len(nonZero) == 0 {
+		return result
+	}
+
+	pos, neg := oddMultiples(nonZero, 1<<(DefaultWindow-2))
+
+	for i := maxLen - 1; i >= 0; i-- {
+		result.SetDouble(result)
+
+		for j := range nonZero {
+			if i >= len(digits[j]) {
+				continue
+			}
+
+			var a *affinePoint
+			switch d := digits[j][i]; {
+			case d > 0:
+				a = pos[j][d/2]
+
+			case d < 0:
+				a = neg[j][-d/2]
+			}
+
+			if a != nil {
+				result.addMixed(result, a)
+			}
+		}
+	}
+
+	return result
+}
+
+// pippenger computes the multi-scalar multiplication with the bucket method.
+// The scalars are split into windows of c bits. For each window, every point is
+// added into the bucket for its c-bit digit and the buckets are then summed up
+// with their weights using two running sums.
+func pippenger(points []*Point, scalars []*big.Int) *Point {
+	batchToAffine(points)
+
+	var (
+		bases   = make([]*affinePoint, 0, len(points))
+		ks      = make([]*big.Int, 0, len(points))
+		maxBits int
+	)
+	for i, p := range points {
+		if p.IsInfinity || scalars[i].Sign() == 0 {
+			continue
+		}
+
+		// Since the buckets only take non-negative digits, the points
+		// of negative scalars are negated instead.
+		a := p.toAffine()
+		if scalars[i].Sign() < 0 {
+			a = a.negate()
+		}
+
+		k := new(big.Int).Abs(scalars[i])
+		if k.BitLen() > maxBits {
+			maxBits = k.BitLen()
+		}
+
+		bases = append(bases, a)
+		ks = append(ks, k)
+	}
+
+	curve := points[0].Curve
+	result := NewInfinityPoint(curve)
+	if len(bases) == 0 {
+		return result
+	}
+
+	c := pippengerWindow(len(bases), maxBits)
+	buckets := make([]Point, 1<<c-1)
+
+	var sum, acc Point
+	for start := (maxBits - 1) / c * c; start >= 0; start -= c {
+		for i := 0; i < c; i++ {
+			result.SetDouble(result)
+		}
+
+		for i := range buckets {
+			buckets[i].setInfinity(curve)
+		}
+
+		for i, k := range ks {
+			if d := window(k, start, c); d != 0 {
+				buckets[d-1].addMixed(&buckets[d-1], bases[i])
+			}
+		}
+
+		// acc = 1*B_1 + 2*B_2 + ... is built by adding up the running
+		// sums B_m, B_m + B_(m-1), ...
+		sum.setInfinity(curve)
+		acc.setInfinity(curve)
+		for i := len(buckets) - 1; i >= 0; i-- {
+			sum.SetAdd(&sum, &buckets[i])
+			acc.SetAdd(&acc, &sum)
+		}
+
+		result.SetAdd(result, &acc)
+	}
+
+	return result
+}
+
+// pippengerWindow returns the window width that minimises the number of point
+// additions needed by pippenger for n points and scalars of the given bit
+// length.
+func pippengerWindow(n, bits int) int {
+	best, bestCost := 1, -1
+	for c := 1; c <= 16; c++ {
+		// Each window needs one addition per point and two per bucket.
+		windows := (bits + c - 1) / c
+		cost := windows * (n + 2<<c)
+
+		if bestCost < 0 || cost < bestCost {
+			best, bestCost = c, cost
+		}
+	}
+
+	return best
+}
+
+// window returns the c bits of k starting at bit start.
+func window(k *big.Int, start, c int) int {
+	var d int
+	for i := c - 1; i >= 0; i-- {
+		d = d<<1 | int(k.Bit(start+i))
+	}
+
+	return d
+}
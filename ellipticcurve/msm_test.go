@@ -0,0 +1,77 @@
+package ellipticcurve
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestMultiScalarMul checks both multi-scalar multiplication algorithms
+// against adding up the separate multiplications.
+func TestMultiScalarMul(t *testing.T) {
+	gens := []*Point{
+		(&testPoint{a: 0, b: 7, x: 47, y: 71}).ToPoint(t, 223),
+		(&testPoint{a: 0, b: 7, x: 192, y: 105}).ToPoint(t, 223),
+		(&testPoint{a: 0, b: 7, x: 17, y: 56}).ToPoint(t, 223),
+		(&testPoint{a: 0, b: 7, infinity: true}).ToPoint(t, 223),
+	}
+
+	rng := rand.New(rand.NewSource(1))
+
+	for _, n := range []int{1, 2, 3, 10, pippengerThreshold + 5} {
+		points := make([]*Point, n)
+		scalars := make([]*big.Int, n)
+		exp := NewInfinityPoint(gens[0].Curve)
+
+		for i := range points {
+			points[i] = gens[rng.Intn(len(gens))].Copy()
+			scalars[i] = big.NewInt(rng.Int63n(2000) - 1000)
+			if i%7 == 0 {
+				scalars[i].SetInt64(0)
+			}
+
+			// Half of the points are left in Jacobian form.
+			if i%2 == 0 {
+				points[i].SetAdd(points[i], gens[0])
+			}
+
+			r, err := points[i].MulWNAF(scalars[i], DefaultWindow)
+			require.NoError(t, err)
+			exp.SetAdd(exp, r)
+		}
+
+		res, err := MultiScalarMul(points, scalars)
+		require.NoError(t, err)
+		require.True(t, exp.Equal(res), "n=%d", n)
+
+		require.True(t, exp.Equal(strauss(points, scalars)), "n=%d", n)
+		require.True(t, exp.Equal(pippenger(points, scalars)), "n=%d", n)
+	}
+
+	// All the terms can cancel out.
+	p := gens[0]
+	res, err := MultiScalarMul(
+		[]*Point{p, p}, []*big.Int{big.NewInt(5), big.NewInt(-5)},
+	)
+	require.NoError(t, err)
+	require.True(t, res.IsInfinity)
+}
+
+// TestMultiScalarMulErrors checks the validation of the inputs of
+// MultiScalarMul.
+func TestMultiScalarMulErrors(t *testing.T) {
+	p := (&testPoint{a: 0, b: 7, x: 47, y: 71}).ToPoint(t, 223)
+	q := (&testPoint{a: 5, b: 7, x: 2, y: 5}).ToPoint(t, 223)
+	one := big.NewInt(1)
+
+	_, err := MultiScalarMul(nil, nil)
+	require.ErrorIs(t, err, ErrNoPoints)
+
+	_, err = MultiScalarMul([]*Point{p}, []*big.Int{one, one})
+	require.ErrorIs(t, err, ErrLengthMismatch)
+
+	_, err = MultiScalarMul([]*Point{p, q}, []*big.Int{one, one})
+	require.ErrorIs(t, err, ErrPointsNotOnSameCurve)
+}
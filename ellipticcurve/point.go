@@ -2,8 +2,10 @@ package ellipticcurve
 
 import (
 	"errors"
+	"fmt"
 	"github.com/ellemouton/schnorr/finitefield"
 	"math/big"
+	"sync/atomic"
 )
 
 var (
@@ -14,218 +16,247 @@ var (
 	// ErrPointNotOnCurve is returned when a given x and y coordinate are
 	// not on the given target curve.
 	ErrPointNotOnCurve = errors.New("points not on curve")
-
-	one = big.NewInt(1)
 )
 
 // Point is a point on a Curve.
+//
+// Internally the Point is held in Jacobian coordinates (X:Y:Z), which stand for
+// the affine point (X/Z^2, Y/Z^3), so that the arithmetic does not need a field
+// inversion for every addition and doubling. The affine coordinates are only
+// computed, once, when they are observed through X or Y.
+//
+// NOTE: X and Y used to be exported fields holding the affine coordinates. They
+// are now methods, and since the coordinates are unexported a Point can no
+// longer be built with a struct literal. Use NewPoint or NewInfinityPoint
+// instead.
 type Point struct {
-	X *finitefield.Element
-	Y *finitefield.Element
+	// x, y and z are the Jacobian coordinates of the point. They are not
+	// used if the point is at infinity.
+	x, y, z finitefield.Element
+
+	// affine caches the affine coordinates of the point once they have
+	// been computed. It is reset whenever the point is changed in place.
+	affine atomic.Pointer[affinePoint]
 
 	*Curve
 
 	// IsInfinity is true if the coordinate is at infinity. If true,
-	// X and Y will be nil and should not be used.
+	// X and Y will return nil.
 	IsInfinity bool
 }
 
-// NewPoint constructs a new Point.
+// affinePoint holds the affine coordinates of a Point that is not at infinity.
+// Once stored in a Point it is never changed.
+type affinePoint struct {
+	x, y *finitefield.Element
+}
+
+// NewPoint constructs a new Point. The Point keeps its own copy of the given
+// coordinates.
 func NewPoint(x, y *finitefield.Element, curve *Curve) (*Point, error) {
 	if !curve.Contains(x, y) {
 		return nil, ErrPointNotOnCurve
 	}
 
-	return &Point{
-		X:     x,
-		Y:     y,
-		Curve: curve,
-	}, nil
+	return new(Point).setAffine(curve, x, y), nil
 }
 
 // NewInfinityPoint constructs a new Point at infinity.
 func NewInfinityPoint(curve *Curve) *Point {
-	return &Point{
-		Curve:      curve,
-		IsInfinity: true,
+	return new(Point).setInfinity(curve)
+}
+
+// X returns the affine X coordinate of the Point or nil if the Point is at
+// infinity. The returned Element is shared with the Point and must not be
+// modified.
+func (p *Point) X() *finitefield.Element {
+	a := p.toAffine()
+	if a == nil {
+		return nil
 	}
+
+	return a.x
+}
+
+// Y returns the affine Y coordinate of the Point or nil if the Point is at
+// infinity. The returned Element is shared with the Point and must not be
+// modified.
+func (p *Point) Y() *finitefield.Element {
+	a := p.toAffine()
+	if a == nil {
+		return nil
+	}
+
+	return a.y
+}
+
+// String returns the affine coordinates of the Point as "(x, y)", or "O" for
+// the point at infinity.
+func (p *Point) String() string {
+	if p.IsInfinity {
+		return "O"
+	}
+
+	return fmt.Sprintf("(%v, %v)", p.X().Num, p.Y().Num)
 }
 
 // Copy returns a copy of the Point.
 func (p *Point) Copy() *Point {
-	return &Point{
-		X:          p.X,
-		Y:          p.Y,
-		Curve:      p.Curve,
-		IsInfinity: p.IsInfinity,
-	}
+	return new(Point).Set(p)
 }
 
 // Equal returns true if Points are the same coordinate on the same curve.
 func (p *Point) Equal(o *Point) bool {
-	if !p.Curve.Equal(o.Curve) {
+	if !p.sameCurve(o) {
 		return false
 	}
 
-	if p.IsInfinity && o.IsInfinity {
-		return true
+	if p.IsInfinity || o.IsInfinity {
+		return p.IsInfinity && o.IsInfinity
 	}
 
-	return p.X.Equal(o.X) && p.Y.Equal(o.Y)
+	// The Jacobian coordinates are only unique up to the choice of Z so
+	// the points are compared by cross multiplying:
+	// x1*z2^2 == x2*z1^2 and y1*z2^3 == y2*z1^3.
+	var zz1, zz2, t1, t2 finitefield.Element
+	zz1.SetSquare(&p.z)
+	zz2.SetSquare(&o.z)
+
+	t1.SetMul(&p.x, &zz2)
+	t2.SetMul(&o.x, &zz1)
+	if !t1.Equal(&t2) {
+		return false
+	}
+
+	t1.SetMul(&p.y, &zz2)
+	t1.SetMul(&t1, &o.z)
+	t2.SetMul(&o.y, &zz1)
+	t2.SetMul(&t2, &p.z)
+
+	return t1.Equal(&t2)
 }
 
 // Add adds the two points together.
 func (p *Point) Add(o *Point) (*Point, error) {
-	if !p.Curve.Equal(o.Curve) {
+	if !p.sameCurve(o) {
 		return nil, ErrPointsNotOnSameCurve
 	}
 
-	if p.IsInfinity {
-		return o.Copy(), nil
-	}
+	return new(Point).SetAdd(p, o), nil
+}
 
-	if o.IsInfinity {
-		return p.Copy(), nil
+// Sub subtracts the given point from this point.
+func (p *Point) Sub(o *Point) (*Point, error) {
+	if !p.sameCurve(o) {
+		return nil, ErrPointsNotOnSameCurve
 	}
 
-	if !o.X.Equal(p.X) {
-		s1, err := o.Y.Sub(p.Y)
-		if err != nil {
-			return nil, err
-		}
-
-		s2, err := o.X.Sub(p.X)
-		if err != nil {
-			return nil, err
-		}
-
-		s, err := s1.Div(s2)
-		if err != nil {
-			return nil, err
-		}
-
-		t1 := s.Pow(two)
-
-		t2, err := t1.Sub(p.X)
-		if err != nil {
-			return nil, err
-		}
-
-		x3, err := t2.Sub(o.X)
-		if err != nil {
-			return nil, err
-		}
+	return new(Point).SetSub(p, o), nil
+}
 
-		t3, err := p.X.Sub(x3)
-		if err != nil {
-			return nil, err
-		}
+// Neg returns the inverse of the point, -p.
+func (p *Point) Neg() *Point {
+	return new(Point).SetNeg(p)
+}
 
-		t4, err := s.Mul(t3)
-		if err != nil {
-			return nil, err
-		}
+// Double returns p + p.
+func (p *Point) Double() *Point {
+	return new(Point).SetDouble(p)
+}
 
-		y3, err := t4.Sub(p.Y)
-		if err != nil {
-			return nil, err
-		}
+// Mul does scalar multiplication on the point.
+//
+// NOTE: this is vulnerable to the side channel leakage attack described in
+//
+//	https://link.springer.com/content/pdf/10.1007/978-3-540-28632-5_14.pdf.
+func (p *Point) Mul(c *big.Int) (*Point, error) {
+	return new(Point).SetMul(p, c), nil
+}
 
-		return NewPoint(x3, y3, p.Curve)
+// ScalarMult returns c*p for any integer c. If the Order of the curve is known
+// then c is reduced modulo it first.
+//
+// NOTE: the time taken depends on the value of c so this must only be used
+// for public scalars.
+func (p *Point) ScalarMult(c *big.Int) *Point {
+	k := c
+	if order := p.Order(); order != nil {
+		k = new(big.Int).Mod(c, order)
 	}
 
-	if !o.Y.Equal(p.Y) {
-		return NewInfinityPoint(p.Curve), nil
+	return new(Point).SetMulWNAF(p, k, DefaultWindow)
+}
+
+// toAffine returns the affine coordinates of the Point, computing and caching
+// them first if needed. nil is returned for the point at infinity.
+func (p *Point) toAffine() *affinePoint {
+	if p.IsInfinity {
+		return nil
 	}
 
-	if o.Y.IsZero() {
-		return NewInfinityPoint(p.Curve), nil
+	if a := p.affine.Load(); a != nil {
+		return a
 	}
 
-	r := p.X.Pow(two)
+	var zInv finitefield.Element
+	a := p.affineWith(zInv.SetInverse(&p.z))
 
-	r1, err := r.Add(r)
-	if err != nil {
-		return nil, err
-	}
+	// Concurrent readers may both get here, in which case they store
+	// equal values.
+	p.affine.Store(a)
 
-	r2, err := r1.Add(r)
-	if err != nil {
-		return nil, err
-	}
+	return a
+}
 
-	r3, err := r2.Add(p.A)
-	if err != nil {
-		return nil, err
-	}
+// batchToAffine computes and caches the affine coordinates of all the given
+// points using a single field inversion. The points must be on the same curve.
+func batchToAffine(points []*Point) {
+	var (
+		todo []*Point
+		zs   []*finitefield.Element
+	)
+	for _, p := range points {
+		if p.IsInfinity || p.affine.Load() != nil {
+			continue
+		}
 
-	n, err := (p.Y).Add(p.Y)
-	if err != nil {
-		return nil, err
+		todo = append(todo, p)
+		zs = append(zs, &p.z)
 	}
 
-	s, err := r3.Div(n)
-	if err != nil {
-		return nil, err
+	if len(todo) == 0 {
+		return
 	}
 
-	s2 := s.Pow(two)
-
-	g, err := (p.X).Add(p.X)
+	invs, err := finitefield.BatchInvert(zs)
 	if err != nil {
-		return nil, err
+		// The Z coordinate of a point that is not at infinity is never
+		// zero and all the points are on the same curve.
+		panic(err)
 	}
 
-	x3, err := s2.Sub(g)
-	if err != nil {
-		return nil, err
+	for i, p := range todo {
+		p.affine.Store(p.affineWith(invs[i]))
 	}
+}
 
-	d, err := p.X.Sub(x3)
-	if err != nil {
-		return nil, err
-	}
+// affineWith returns the affine coordinates x = X/Z^2 and y = Y/Z^3 of the
+// point given the inverse of its Z coordinate.
+func (p *Point) affineWith(zInv *finitefield.Element) *affinePoint {
+	var t finitefield.Element
+	t.SetSquare(zInv)
 
-	d1, err := s.Mul(d)
-	if err != nil {
-		return nil, err
+	a := &affinePoint{
+		x: new(finitefield.Element).SetMul(&p.x, &t),
 	}
 
-	y3, err := d1.Sub(p.Y)
-	if err != nil {
-		return nil, err
-	}
+	t.SetMul(&t, zInv)
+	a.y = new(finitefield.Element).SetMul(&p.y, &t)
 
-	return NewPoint(x3, y3, p.Curve)
+	return a
 }
 
-// Mul does scalar multiplication on the point.
-//
-// NOTE: this is vulnerable to the side channel leakage attack described in
-//  https://link.springer.com/content/pdf/10.1007/978-3-540-28632-5_14.pdf.
-func (p *Point) Mul(c *big.Int) (*Point, error) {
-	var coef big.Int
-	coef.Set(c)
-
-	current := p.Copy()
-	result := NewInfinityPoint(p.Curve)
-
-	var err error
-	for coef.Sign() > 0 {
-		if new(big.Int).And(&coef, one).Cmp(one) == 0 {
-			result, err = result.Add(current)
-			if err != nil {
-				return nil, err
-			}
-		}
-
-		current, err = current.Add(current)
-		if err != nil {
-			return nil, err
-		}
-
-		coef.Rsh(&coef, 1)
-	}
-
-	return result, nil
+// sameCurve returns true if the two Points are on the same curve.
+func (p *Point) sameCurve(o *Point) bool {
+	return p.Curve == o.Curve || p.Curve.Equal(o.Curve)
 }
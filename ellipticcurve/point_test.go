@@ -21,7 +21,8 @@ func TestNewPoint(t *testing.T) {
 	y, err := finitefield.NewElement(big.NewInt(105), big.NewInt(223))
 	require.NoError(t, err)
 
-	curve := NewCurve(a, b)
+	curve, err := NewCurve(a, b)
+	require.NoError(t, err)
 
 	p1, err := NewPoint(x, y, curve)
 	require.NoError(t, err)
@@ -34,7 +35,9 @@ func TestNewPoint(t *testing.T) {
 	c, err := finitefield.NewElement(big.NewInt(6), big.NewInt(223))
 	require.NoError(t, err)
 
-	curve2 := NewCurve(a, c)
+	curve2, err := NewCurve(a, c)
+	require.NoError(t, err)
+
 	_, err = NewPoint(x, y, curve2)
 	require.Error(t, err)
 }
@@ -48,7 +51,7 @@ type testPoint struct {
 	infinity bool
 }
 
-func (m *testPoint) ToPoint(t *testing.T, p int64) *Point {
+func (m *testPoint) ToPoint(t testing.TB, p int64) *Point {
 	prime := big.NewInt(p)
 
 	a, err := finitefield.NewElement(big.NewInt(m.a), prime)
@@ -57,7 +60,8 @@ func (m *testPoint) ToPoint(t *testing.T, p int64) *Point {
 	b, err := finitefield.NewElement(big.NewInt(m.b), prime)
 	require.NoError(t, err)
 
-	curve := NewCurve(a, b)
+	curve, err := NewCurve(a, b)
+	require.NoError(t, err)
 
 	if m.infinity {
 		return NewInfinityPoint(curve)
@@ -192,3 +196,143 @@ func TestPointMul(t *testing.T) {
 		})
 	}
 }
+
+// affineAdd adds the two points with the textbook affine formulas. It is used
+// as a reference for the Jacobian arithmetic.
+func affineAdd(t *testing.T, p, q *Point) *Point {
+	switch {
+	case p.IsInfinity:
+		return q.Copy()
+
+	case q.IsInfinity:
+		return p.Copy()
+
+	case p.X().Equal(q.X()) && (!p.Y().Equal(q.Y()) || p.Y().IsZero()):
+		return NewInfinityPoint(p.Curve)
+	}
+
+	var s finitefield.Element
+	if p.X().Equal(q.X()) {
+		// s = (3x^2 + a) / 2y
+		var t1, t2 finitefield.Element
+		t1.SetSquare(p.X())
+		t2.SetAdd(&t1, &t1)
+		t1.SetAdd(&t1, &t2)
+		t1.SetAdd(&t1, p.A)
+		t2.SetAdd(p.Y(), p.Y())
+		s.SetDiv(&t1, &t2)
+	} else {
+		// s = (y2 - y1) / (x2 - x1)
+		var t1, t2 finitefield.Element
+		t1.SetSub(q.Y(), p.Y())
+		t2.SetSub(q.X(), p.X())
+		s.SetDiv(&t1, &t2)
+	}
+
+	// x3 = s^2 - x1 - x2 and y3 = s(x1 - x3) - y1
+	var x3, y3 finitefield.Element
+	x3.SetSquare(&s)
+	x3.SetSub(&x3, p.X())
+	x3.SetSub(&x3, q.X())
+	y3.SetSub(p.X(), &x3)
+	y3.SetMul(&s, &y3)
+	y3.SetSub(&y3, p.Y())
+
+	r, err := NewPoint(&x3, &y3, p.Curve)
+	require.NoError(t, err)
+
+	return r
+}
+
+// TestPointJacobian walks through every multiple of a generator on curves with
+// and without a zero A coefficient and checks the Jacobian arithmetic against
+// the affine formulas.
+func TestPointJacobian(t *testing.T) {
+	generators := []*Point{
+		(&testPoint{a: 0, b: 7, x: 47, y: 71}).ToPoint(t, 223),
+		(&testPoint{a: 0, b: 7, x: 192, y: 105}).ToPoint(t, 223),
+		(&testPoint{a: 5, b: 7, x: 2, y: 5}).ToPoint(t, 223),
+	}
+
+	for _, g := range generators {
+		exp := NewInfinityPoint(g.Curve)
+		acc := NewInfinityPoint(g.Curve)
+
+		for c := int64(1); c <= 250; c++ {
+			exp = affineAdd(t, exp, g)
+
+			// acc is only ever changed in place, so it stays in
+			// Jacobian form with a growing Z coordinate.
+			acc.SetAdd(acc, g)
+			require.True(t, exp.Equal(acc), "c=%d", c)
+			require.Equal(t, exp.IsInfinity, acc.IsInfinity)
+
+			if acc.IsInfinity {
+				require.Nil(t, acc.X())
+				require.Nil(t, acc.Y())
+
+				continue
+			}
+
+			require.True(t, acc.Contains(acc.X(), acc.Y()))
+			require.True(t, exp.X().Equal(acc.X()))
+			require.True(t, exp.Y().Equal(acc.Y()))
+
+			// Doubling and multiplying a point that is not in
+			// affine form give the same results as the reference.
+			jac := acc.Copy()
+			jac.SetAdd(jac, g)
+			jac.SetAdd(jac, new(Point).SetMul(g, big.NewInt(c-1)))
+
+			double := affineAdd(t, exp, exp)
+			require.True(t, double.Equal(jac))
+			require.True(t, double.Equal(new(Point).SetDouble(acc)))
+
+			mul := new(Point).SetMul(jac, big.NewInt(3))
+			triple := affineAdd(t, affineAdd(t, double, double), double)
+			require.True(t, triple.Equal(mul))
+		}
+	}
+}
+
+// TestPointNegSubDouble checks Neg, Sub and Double against the affine
+// reference for points with and without cached affine coordinates.
+func TestPointNegSubDouble(t *testing.T) {
+	g := (&testPoint{a: 0, b: 7, x: 47, y: 71}).ToPoint(t, 223)
+	q := (&testPoint{a: 0, b: 7, x: 192, y: 105}).ToPoint(t, 223)
+
+	inf := NewInfinityPoint(g.Curve)
+	require.True(t, inf.Neg().IsInfinity)
+	require.True(t, inf.Double().IsInfinity)
+
+	// acc is only changed in place so it stays in Jacobian form, and its
+	// affine coordinates are not known until they are first observed.
+	acc := NewInfinityPoint(g.Curve)
+	for c := int64(1); c <= 21; c++ {
+		acc.SetAdd(acc, g)
+
+		neg := acc.Neg()
+		double := acc.Double()
+		diff, err := acc.Sub(q)
+		require.NoError(t, err)
+
+		require.True(t, negate(t, acc).Equal(neg), "c=%d", c)
+		require.True(t, new(Point).SetAdd(acc, neg).IsInfinity)
+		require.True(t, affineAdd(t, acc, acc).Equal(double))
+		require.True(t, affineAdd(t, acc, negate(t, q)).Equal(diff))
+
+		diff, err = acc.Sub(acc)
+		require.NoError(t, err)
+		require.True(t, diff.IsInfinity)
+
+		if acc.IsInfinity {
+			continue
+		}
+
+		// Negating a point whose affine coordinates are known
+		// negates them too.
+		neg = acc.Neg()
+		require.True(t, acc.X().Equal(neg.X()))
+		require.True(t, acc.Y().Negate().Equal(neg.Y()))
+	}
+}
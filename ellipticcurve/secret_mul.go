@@ -0,0 +1,219 @@
+package ellipticcurve
+
+import (
+	"crypto/subtle"
+
+	"github.com/ellemouton/schnorr/finitefield"
+)
+
+// The scalar multiplication in this file is for secret scalars such as private
+// keys and nonces on curves that do not have a dedicated implementation. Unlike
+// ScalarMult, which branches on the bits of the scalar and works on big.Int
+// coordinates, it runs in constant time: it uses SecretElement arithmetic,
+// complete addition formulas that do not branch on the points, a fixed window
+// over every bit of the scalar and table lookups that read every entry.
+
+const (
+	// secretWindow is the number of scalar bits handled per addition by
+	// MulSecret.
+	secretWindow = 4
+
+	// secretTableSize is the number of multiples of the point held in
+	// the table used by MulSecret.
+	secretTableSize = 1 << secretWindow
+)
+
+// secretPoint is a point in homogeneous projective coordinates (X:Y:Z), which
+// stand for the affine point (X/Z, Y/Z). The point at infinity is (0:1:0).
+//
+// The arithmetic uses the complete formulas for any a from "Complete addition
+// formulas for prime order elliptic curves" by Renes, Costello and Batina
+// (https://eprint.iacr.org/2015/1060.pdf). On a curve of odd order they are
+// correct for every pair of inputs, including doubling and the point at
+// infinity. On a curve with an even number of points they are still correct
+// for every pair of points of a subgroup of odd order, such as the multiples
+// of a point of prime order.
+type secretPoint struct {
+	x, y, z *finitefield.SecretElement
+}
+
+// secretCurve holds the constants of a Curve used by the complete formulas.
+type secretCurve struct {
+	a, b3 *finitefield.SecretElement
+}
+
+// MulSecret returns k*p, where k is the big-endian encoding of the scalar.
+// Unlike ScalarMult, the multiplication runs in constant time so it must be
+// used whenever k is secret. The time taken only depends on the length of k
+// and the size of the field.
+//
+// NOTE: the result is only correct if p is in a subgroup of odd order, which
+// is always the case on curves of odd order. See secretPoint.
+func (p *Point) MulSecret(k []byte) *Point {
+	c := &secretCurve{
+		a:  p.A.Secret(),
+		b3: sMul(finiteConst(p.B, 3).Secret(), p.B.Secret()),
+	}
+
+	var table [secretTableSize]*secretPoint
+	table[0] = newSecretInfinity(p.Curve)
+	table[1] = newSecretPoint(p)
+	for i := 2; i < secretTableSize; i++ {
+		table[i] = c.add(table[i-1], table[1])
+	}
+
+	// Walk through the scalar one window at a time starting with the
+	// most significant one.
+	r := table[0]
+	for i := 0; i < 2*len(k); i++ {
+		for j := 0; j < secretWindow; j++ {
+			r = c.add(r, r)
+		}
+
+		nibble := k[i/2] >> (4 * (1 - i%2)) & 0xf
+		r = c.add(r, lookupSecret(&table, nibble))
+	}
+
+	return r.toPoint(p.Curve)
+}
+
+// newSecretInfinity returns the point at infinity of the curve.
+func newSecretInfinity(c *Curve) *secretPoint {
+	return &secretPoint{
+		x: finiteConst(c.A, 0).Secret(),
+		y: finiteConst(c.A, 1).Secret(),
+		z: finiteConst(c.A, 0).Secret(),
+	}
+}
+
+// newSecretPoint returns the given point in projective coordinates.
+func newSecretPoint(p *Point) *secretPoint {
+	if p.IsInfinity {
+		return newSecretInfinity(p.Curve)
+	}
+
+	return &secretPoint{
+		x: p.X().Secret(),
+		y: p.Y().Secret(),
+		z: finiteConst(p.A, 1).Secret(),
+	}
+}
+
+// toPoint returns the affine form of r on the given curve.
+func (r *secretPoint) toPoint(c *Curve) *Point {
+	if r.z.IsZero() == 1 {
+		return NewInfinityPoint(c)
+	}
+
+	zInv := r.z.Inverse()
+	x := sMul(r.x, zInv).Element()
+	y := sMul(r.y, zInv).Element()
+
+	p, err := NewPoint(x, y, c)
+	if err != nil {
+		// The formulas always produce a point on the curve.
+		panic(err)
+	}
+
+	return p
+}
+
+// add returns p + q. This is algorithm 1 of Renes, Costello and Batina, which
+// is also used for doubling.
+func (c *secretCurve) add(p, q *secretPoint) *secretPoint {
+	t0 := sMul(p.x, q.x)
+	t1 := sMul(p.y, q.y)
+	t2 := sMul(p.z, q.z)
+	t3 := sAdd(p.x, p.y)
+	t4 := sAdd(q.x, q.y)
+	t3 = sMul(t3, t4)
+	t4 = sAdd(t0, t1)
+	t3 = sSub(t3, t4)
+	t4 = sAdd(p.x, p.z)
+	t5 := sAdd(q.x, q.z)
+	t4 = sMul(t4, t5)
+	t5 = sAdd(t0, t2)
+	t4 = sSub(t4, t5)
+	t5 = sAdd(p.y, p.z)
+	x3 := sAdd(q.y, q.z)
+	t5 = sMul(t5, x3)
+	x3 = sAdd(t1, t2)
+	t5 = sSub(t5, x3)
+	z3 := sMul(c.a, t4)
+	x3 = sMul(c.b3, t2)
+	z3 = sAdd(x3, z3)
+	x3 = sSub(t1, z3)
+	z3 = sAdd(t1, z3)
+	y3 := sMul(x3, z3)
+	t1 = sAdd(t0, t0)
+	t1 = sAdd(t1, t0)
+	t2 = sMul(c.a, t2)
+	t4 = sMul(c.b3, t4)
+	t1 = sAdd(t1, t2)
+	t2 = sSub(t0, t2)
+	t2 = sMul(c.a, t2)
+	t4 = sAdd(t4, t2)
+	t0 = sMul(t1, t4)
+	y3 = sAdd(y3, t0)
+	t0 = sMul(t5, t4)
+	x3 = sMul(t3, x3)
+	x3 = sSub(x3, t0)
+	t0 = sMul(t3, t1)
+	z3 = sMul(t5, z3)
+	z3 = sAdd(z3, t0)
+
+	return &secretPoint{x: x3, y: y3, z: z3}
+}
+
+// lookupSecret returns table[idx]. Every entry of the table is read so that
+// the memory access pattern does not depend on idx.
+func lookupSecret(table *[secretTableSize]*secretPoint,
+	idx byte) *secretPoint {
+
+	r := table[0]
+	for i := 1; i < secretTableSize; i++ {
+		choice := subtle.ConstantTimeByteEq(byte(i), idx)
+
+		r = &secretPoint{
+			x: sSelect(r.x, table[i].x, choice),
+			y: sSelect(r.y, table[i].y, choice),
+			z: sSelect(r.z, table[i].z, choice),
+		}
+	}
+
+	return r
+}
+
+// sAdd returns a + b. The SecretElements of a secretPoint are always in the
+// field of its curve so the arithmetic helpers below panic on an error.
+func sAdd(a, b *finitefield.SecretElement) *finitefield.SecretElement {
+	return mustSecret(a.Add(b))
+}
+
+// sSub returns a - b.
+func sSub(a, b *finitefield.SecretElement) *finitefield.SecretElement {
+	return mustSecret(a.Sub(b))
+}
+
+// sMul returns a * b.
+func sMul(a, b *finitefield.SecretElement) *finitefield.SecretElement {
+	return mustSecret(a.Mul(b))
+}
+
+// sSelect returns b if choice is 1 and a if choice is 0.
+func sSelect(a, b *finitefield.SecretElement,
+	choice int) *finitefield.SecretElement {
+
+	return mustSecret(a.Select(b, choice))
+}
+
+// mustSecret returns e and panics if err is not nil.
+func mustSecret(e *finitefield.SecretElement,
+	err error) *finitefield.SecretElement {
+
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
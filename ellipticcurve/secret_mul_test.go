@@ -0,0 +1,41 @@
+package ellipticcurve
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestMulSecret checks the constant-time multiplication against ScalarMult for
+// every point of a curve of odd order with a != 0, and for the points of order
+// 7 of a curve with an even number of points.
+func TestMulSecret(t *testing.T) {
+	// y^2 = x^3 + 2x + 2 over F_17 has 19 points.
+	c := toyCurve(t, 2, 2, 17)
+
+	points, err := c.Points()
+	require.NoError(t, err)
+
+	for _, p := range points {
+		for k := 0; k < 300; k += 7 {
+			kb := big.NewInt(int64(k)).FillBytes(make([]byte, 2))
+
+			exp := p.ScalarMult(big.NewInt(int64(k)))
+			res := p.MulSecret(kb)
+			require.True(t, exp.Equal(res), "%v %d", p, k)
+			require.Equal(t, exp.IsInfinity, res.IsInfinity)
+		}
+	}
+
+	// y^2 = x^3 + 7 over F_223 has 252 = 7*36 points and (15, 137)
+	// generates the subgroup of order 7.
+	c = toyCurve(t, 0, 7, 223)
+	g, err := NewPoint(finiteConst(c.A, 15), finiteConst(c.A, 137), c)
+	require.NoError(t, err)
+
+	for k := int64(0); k < 20; k++ {
+		exp := g.ScalarMult(big.NewInt(k))
+		require.True(t, exp.Equal(g.MulSecret([]byte{byte(k)})))
+	}
+}
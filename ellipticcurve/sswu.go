@@ -0,0 +1,65 @@
+package ellipticcurve
+
+import (
+	"errors"
+
+	"github.com/ellemouton/schnorr/finitefield"
+)
+
+// ErrSSWUUnsupported is returned when the simplified SWU map is used with a
+// curve that has A = 0 or B = 0, which it does not support. Such curves, like
+// secp256k1, are instead mapped to through an isogenous curve.
+var ErrSSWUUnsupported = errors.New("simplified SWU needs a curve with " +
+	"A != 0 and B != 0")
+
+// MapToCurveSSWU maps the field element u to a point on the curve using the
+// simplified Shallue-van de Woestijne-Ulas method from section 6.6.2 of RFC
+// 9380. z is the non-square constant of the map, which must meet the criteria
+// of section H.2 of the RFC for the map to be well defined. The sign of the Y
+// coordinate of the result matches the sign, or parity, of u.
+//
+// NOTE: the map branches on its input so it must not be used on secret values.
+func (c *Curve) MapToCurveSSWU(u, z *finitefield.Element) (*Point, error) {
+	if c.A.IsZero() || c.B.IsZero() {
+		return nil, ErrSSWUUnsupported
+	}
+
+	// tv1 = 1 / (z^2 * u^4 + z * u^2), or 0 if the denominator is 0.
+	var zu2, tv1, x1, x2, t finitefield.Element
+	zu2.SetSquare(u)
+	zu2.SetMul(&zu2, z)
+	tv1.SetSquare(&zu2)
+	tv1.SetAdd(&tv1, &zu2)
+
+	if tv1.IsZero() {
+		// x1 = B / (z * A)
+		t.SetMul(z, c.A)
+		x1.SetDiv(c.B, &t)
+	} else {
+		// x1 = (-B / A) * (1 + tv1)
+		tv1.SetInverse(&tv1)
+		tv1.SetAdd(&tv1, finiteConst(u, 1))
+		t.SetNegate(c.B)
+		t.SetDiv(&t, c.A)
+		x1.SetMul(&t, &tv1)
+	}
+
+	// If x1^3 + A*x1 + B is not a square then z * u^2 * (x1^3 + A*x1 + B)
+	// is, and it is the value of the curve equation at x2 = z * u^2 * x1.
+	x, gx := &x1, c.rhs(&x1)
+	if !gx.IsSquare() {
+		x2.SetMul(&zu2, &x1)
+		x, gx = &x2, c.rhs(&x2)
+	}
+
+	y, err := gx.Sqrt()
+	if err != nil {
+		return nil, err
+	}
+
+	if y.Num.Bit(0) != u.Num.Bit(0) {
+		y.SetNegate(y)
+	}
+
+	return NewPoint(x, y, c)
+}
@@ -0,0 +1,40 @@
+package ellipticcurve
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ellemouton/schnorr/finitefield"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMapToCurveSSWU checks that every element of a small field is mapped to a
+// point on the curve with a Y coordinate of the same parity.
+func TestMapToCurveSSWU(t *testing.T) {
+	// y^2 = x^3 + 2x + 2 over F_17 with Z = 3.
+	c := toyCurve(t, 2, 2, 17)
+
+	f, err := finitefield.NewField(big.NewInt(17))
+	require.NoError(t, err)
+	z := f.FromInt(3)
+
+	for i := int64(0); i < 17; i++ {
+		u := f.FromInt(i)
+
+		p, err := c.MapToCurveSSWU(u, z)
+		require.NoError(t, err)
+		require.False(t, p.IsInfinity)
+		require.True(t, c.Contains(p.X(), p.Y()))
+		require.Equal(t, u.Num.Bit(0), p.Y().Num.Bit(0))
+
+		// u and -u are mapped to the same X coordinate.
+		q, err := c.MapToCurveSSWU(u.Negate(), z)
+		require.NoError(t, err)
+		require.True(t, p.X().Equal(q.X()))
+	}
+
+	_, err = toyCurve(t, 0, 7, 223).MapToCurveSSWU(
+		f.FromInt(1), f.FromInt(3),
+	)
+	require.ErrorIs(t, err, ErrSSWUUnsupported)
+}
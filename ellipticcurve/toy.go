@@ -0,0 +1,306 @@
+package ellipticcurve
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"text/tabwriter"
+
+	"github.com/ellemouton/schnorr/finitefield"
+)
+
+// The functions in this file are for exploring small "toy" curves, such as
+// y^2 = x^3 + 7 over F_223, by brute force. They are meant for teaching and
+// for building intuition about the group structure of elliptic curves and only
+// work for curves over fields of order at most MaxToyPrime.
+
+const (
+	// MaxToyPrime is the largest field order for which the points of a
+	// curve are enumerated.
+	MaxToyPrime = 1 << 16
+
+	// MaxGroupTablePoints is the largest number of points for which
+	// WriteGroupTable writes the addition table.
+	MaxGroupTablePoints = 64
+)
+
+// ErrCurveTooLarge is returned when a curve is too large to be explored by
+// brute force.
+var ErrCurveTooLarge = errors.New("curve is too large to enumerate")
+
+// Subgroup is a subgroup of prime order of the points on a curve.
+type Subgroup struct {
+	// G generates the subgroup.
+	G *Point
+
+	// N is the prime order of the subgroup.
+	N *big.Int
+
+	// H is the cofactor, the number of points on the curve divided by N.
+	H *big.Int
+}
+
+// Points returns all the points on the curve. The point at infinity comes
+// first, followed by the other points ordered by their X and then their Y
+// coordinate.
+func (c *Curve) Points() ([]*Point, error) {
+	if err := c.checkToy(); err != nil {
+		return nil, err
+	}
+
+	points := []*Point{NewInfinityPoint(c)}
+
+	err := c.forEachX(func(x, rhs *finitefield.Element) error {
+		if rhs.IsZero() {
+			p, err := NewPoint(x, rhs, c)
+			if err != nil {
+				return err
+			}
+
+			points = append(points, p)
+
+			return nil
+		}
+
+		if !rhs.IsSquare() {
+			return nil
+		}
+
+		y, err := rhs.Sqrt()
+		if err != nil {
+			return err
+		}
+
+		// Order the two square roots.
+		ys := []*finitefield.Element{y, y.Negate()}
+		if ys[0].Num.Cmp(ys[1].Num) > 0 {
+			ys[0], ys[1] = ys[1], ys[0]
+		}
+
+		for _, y := range ys {
+			p, err := NewPoint(x, y, c)
+			if err != nil {
+				return err
+			}
+
+			points = append(points, p)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return points, nil
+}
+
+// CountPoints returns the number of points on the curve, including the point
+// at infinity. Unlike Points, it only needs a Legendre symbol for each X
+// coordinate and no square roots.
+func (c *Curve) CountPoints() (*big.Int, error) {
+	if err := c.checkToy(); err != nil {
+		return nil, err
+	}
+
+	// Each X coordinate gives 1 + (rhs/p) points.
+	count := int64(1)
+	err := c.forEachX(func(_, rhs *finitefield.Element) error {
+		count += int64(1 + rhs.Legendre())
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return big.NewInt(count), nil
+}
+
+// PointOrder returns the order of the point, the smallest positive n for which
+// n*P is the point at infinity. The order of the curve is used if it is known
+// and the points are counted otherwise.
+func (p *Point) PointOrder() (*big.Int, error) {
+	// The order of the group has to be factored, which is only feasible
+	// for small curves.
+	if err := p.checkToy(); err != nil {
+		return nil, err
+	}
+
+	order := p.Order()
+	if order == nil {
+		var err error
+		order, err = p.CountPoints()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return p.pointOrder(order, primeFactors(order)), nil
+}
+
+// pointOrder returns the order of the point given the order of the group it is
+// in and the prime factors of that order.
+func (p *Point) pointOrder(order *big.Int, factors []*big.Int) *big.Int {
+	// The order of the point divides the order of the group, so remove
+	// each prime factor for as long as the point still vanishes.
+	var (
+		n   = new(big.Int).Set(order)
+		q   big.Int
+		rem big.Int
+		r   Point
+	)
+	for _, f := range factors {
+		for {
+			q.QuoRem(n, f, &rem)
+			if rem.Sign() != 0 {
+				break
+			}
+
+			if !r.SetMulWNAF(p, &q, DefaultWindow).IsInfinity {
+				break
+			}
+
+			n.Set(&q)
+		}
+	}
+
+	return n
+}
+
+// PrimeOrderSubgroups returns a generator of a subgroup of order q for each
+// prime q that divides the number of points on the curve, ordered by q. If the
+// group is not cyclic there can be several subgroups of order q, in which case
+// only one of them is returned.
+func (c *Curve) PrimeOrderSubgroups() ([]*Subgroup, error) {
+	points, err := c.Points()
+	if err != nil {
+		return nil, err
+	}
+
+	// Every point is on the curve, so the order of the group and its
+	// factors only have to be computed once rather than once per point.
+	order := big.NewInt(int64(len(points)))
+	factors := primeFactors(order)
+
+	orders := make([]*big.Int, len(points))
+	for i, p := range points {
+		orders[i] = p.pointOrder(order, factors)
+	}
+
+	var (
+		subgroups []*Subgroup
+		rem       big.Int
+	)
+	for _, q := range factors {
+		// If the order m of a point is a multiple of q, then (m/q)*P
+		// has order q. The group need not be cyclic so this does not
+		// work with the cofactor instead of m/q.
+		for i, p := range points {
+			m, _ := new(big.Int).QuoRem(orders[i], q, &rem)
+			if rem.Sign() != 0 {
+				continue
+			}
+
+			subgroups = append(subgroups, &Subgroup{
+				G: new(Point).SetMulWNAF(p, m, DefaultWindow),
+				N: q,
+				H: new(big.Int).Quo(order, q),
+			})
+
+			break
+		}
+	}
+
+	return subgroups, nil
+}
+
+// WriteGroupTable writes the addition table of all the points on the curve to
+// w. The entry in the row of P and the column of Q is P + Q. ErrCurveTooLarge
+// is returned if the curve has more than MaxGroupTablePoints points.
+func (c *Curve) WriteGroupTable(w io.Writer) error {
+	points, err := c.Points()
+	if err != nil {
+		return err
+	}
+
+	if len(points) > MaxGroupTablePoints {
+		return fmt.Errorf("%w: %d points is more than %d", ErrCurveTooLarge,
+			len(points), MaxGroupTablePoints)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', tabwriter.AlignRight)
+
+	fmt.Fprint(tw, "+\t")
+	for _, q := range points {
+		fmt.Fprintf(tw, "%v\t", q)
+	}
+	fmt.Fprintln(tw)
+
+	var sum Point
+	for _, p := range points {
+		fmt.Fprintf(tw, "%v\t", p)
+		for _, q := range points {
+			fmt.Fprintf(tw, "%v\t", sum.SetAdd(p, q))
+		}
+		fmt.Fprintln(tw)
+	}
+
+	return tw.Flush()
+}
+
+// checkToy returns ErrCurveTooLarge if the curve is over a field that is too
+// large to enumerate.
+func (c *Curve) checkToy() error {
+	if c.A.P.Cmp(big.NewInt(MaxToyPrime)) > 0 {
+		return fmt.Errorf("%w: field order %v is larger than %d",
+			ErrCurveTooLarge, c.A.P, MaxToyPrime)
+	}
+
+	return nil
+}
+
+// forEachX calls fn with every X coordinate in the field along with the right
+// hand side x^3 + ax + b of the curve equation.
+func (c *Curve) forEachX(fn func(x, rhs *finitefield.Element) error) error {
+	p := c.A.P.Int64()
+
+	for i := int64(0); i < p; i++ {
+		x, err := finitefield.NewElement(big.NewInt(i), c.A.P)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(x, c.rhs(x)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// primeFactors returns the distinct prime factors of n in increasing order.
+// It uses trial division so n must be small.
+func primeFactors(n *big.Int) []*big.Int {
+	var (
+		factors []*big.Int
+		m       = n.Int64()
+	)
+	for f := int64(2); f*f <= m; f++ {
+		if m%f != 0 {
+			continue
+		}
+
+		factors = append(factors, big.NewInt(f))
+		for m%f == 0 {
+			m /= f
+		}
+	}
+
+	if m > 1 {
+		factors = append(factors, big.NewInt(m))
+	}
+
+	return factors
+}
@@ -0,0 +1,139 @@
+package ellipticcurve
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ellemouton/schnorr/finitefield"
+	"github.com/stretchr/testify/require"
+)
+
+// toyCurve returns the curve y^2 = x^3 + ax + b over F_p.
+func toyCurve(t *testing.T, a, b, p int64) *Curve {
+	f, err := finitefield.NewField(big.NewInt(p))
+	require.NoError(t, err)
+
+	c, err := NewCurve(f.FromInt(a), f.FromInt(b))
+	require.NoError(t, err)
+
+	return c
+}
+
+// TestPoints checks the enumeration and counting of the points of a curve and
+// the orders of the points.
+func TestPoints(t *testing.T) {
+	c := toyCurve(t, 0, 7, 223)
+
+	points, err := c.Points()
+	require.NoError(t, err)
+	require.Len(t, points, 252)
+	require.True(t, points[0].IsInfinity)
+
+	count, err := c.CountPoints()
+	require.NoError(t, err)
+	require.Equal(t, int64(252), count.Int64())
+
+	for i, p := range points[1:] {
+		require.True(t, c.Contains(p.X(), p.Y()))
+
+		// The points are distinct and in order.
+		prev := points[i]
+		if !prev.IsInfinity {
+			cmp := prev.X().Num.Cmp(p.X().Num)
+			if cmp == 0 {
+				cmp = prev.Y().Num.Cmp(p.Y().Num)
+			}
+			require.Negative(t, cmp)
+		}
+
+		// The order of each point divides the order of the group and
+		// is the smallest multiple that gives infinity.
+		order, err := p.PointOrder()
+		require.NoError(t, err)
+		require.Zero(t, new(big.Int).Mod(count, order).Sign())
+		require.True(t, p.ScalarMult(order).IsInfinity)
+
+		for _, f := range primeFactors(order) {
+			q := new(big.Int).Quo(order, f)
+			require.False(t, p.ScalarMult(q).IsInfinity)
+		}
+	}
+
+	order, err := points[0].PointOrder()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), order.Int64())
+
+	for _, test := range []struct {
+		x, y, order int64
+	}{
+		{x: 47, y: 71, order: 21},
+		{x: 192, y: 105, order: 42},
+		{x: 15, y: 137, order: 7},
+	} {
+		p := (&testPoint{a: 0, b: 7, x: test.x, y: test.y}).ToPoint(
+			t, 223,
+		)
+
+		order, err := p.PointOrder()
+		require.NoError(t, err)
+		require.Equal(t, test.order, order.Int64())
+	}
+}
+
+// TestPrimeOrderSubgroups checks that a generator is found for every prime
+// that divides the order of the group.
+func TestPrimeOrderSubgroups(t *testing.T) {
+	c := toyCurve(t, 0, 7, 223)
+
+	subgroups, err := c.PrimeOrderSubgroups()
+	require.NoError(t, err)
+	require.Len(t, subgroups, 3)
+
+	for i, q := range []int64{2, 3, 7} {
+		s := subgroups[i]
+		require.Equal(t, q, s.N.Int64())
+		require.Equal(t, 252/q, s.H.Int64())
+
+		order, err := s.G.PointOrder()
+		require.NoError(t, err)
+		require.Equal(t, q, order.Int64())
+	}
+}
+
+// TestWriteGroupTable checks the addition table of a small curve of prime
+// order.
+func TestWriteGroupTable(t *testing.T) {
+	// y^2 = x^3 + 2x + 2 over F_17 has 19 points.
+	c := toyCurve(t, 2, 2, 17)
+
+	var buf bytes.Buffer
+	require.NoError(t, c.WriteGroupTable(&buf))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 20)
+
+	// Adding the point at infinity changes nothing so the row of O is the
+	// same as the header.
+	header := strings.Fields(lines[0])
+	rowO := strings.Fields(lines[1])
+	require.Equal(t, []string{"+", "O"}, header[:2])
+	require.Equal(t, "O", rowO[0])
+	require.Equal(t, header[1:], rowO[1:])
+
+	// The table of y^2 = x^3 + 7 over F_223 is too large.
+	err := toyCurve(t, 0, 7, 223).WriteGroupTable(&buf)
+	require.ErrorIs(t, err, ErrCurveTooLarge)
+}
+
+// TestToyCurveTooLarge checks that large curves are not enumerated.
+func TestToyCurveTooLarge(t *testing.T) {
+	c := toyCurve(t, 0, 7, 65537)
+
+	_, err := c.Points()
+	require.ErrorIs(t, err, ErrCurveTooLarge)
+
+	_, err = c.CountPoints()
+	require.ErrorIs(t, err, ErrCurveTooLarge)
+}
@@ -0,0 +1,167 @@
+package ellipticcurve
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ellemouton/schnorr/finitefield"
+)
+
+const (
+	// MinWindow is the smallest window width accepted by MulWNAF.
+	MinWindow = 2
+
+	// MaxWindow is the largest window width accepted by MulWNAF. A window
+	// of width w needs a table of 2^(w-2) precomputed points.
+	MaxWindow = 8
+
+	// DefaultWindow is a window width that works well for 256-bit
+	// scalars.
+	DefaultWindow = 5
+)
+
+// ErrInvalidWindow is returned when a window width outside of the range
+// [MinWindow, MaxWindow] is given.
+var ErrInvalidWindow = errors.New("invalid wNAF window width")
+
+// MulWNAF does scalar multiplication on the point using the windowed non-adjacent
+// form (wNAF) of c with a window of width w. c may be negative.
+//
+// NOTE: the time taken depends on the value of c so this must only be used
+// for public scalars, such as during signature verification.
+func (p *Point) MulWNAF(c *big.Int, w uint) (*Point, error) {
+	if w < MinWindow || w > MaxWindow {
+		return nil, ErrInvalidWindow
+	}
+
+	return new(Point).SetMulWNAF(p, c, w), nil
+}
+
+// SetMulWNAF sets z to c*p using a wNAF window of width w and returns z. It
+// panics with ErrInvalidWindow if w is not in the range [MinWindow,
+// MaxWindow]. See MulWNAF.
+func (z *Point) SetMulWNAF(p *Point, c *big.Int, w uint) *Point {
+	if w < MinWindow || w > MaxWindow {
+		panic(ErrInvalidWindow)
+	}
+
+	var result Point
+	result.setInfinity(p.Curve)
+
+	if p.IsInfinity || c.Sign() == 0 {
+		return z.Set(&result)
+	}
+
+	digits := wnaf(c, w)
+	pos, neg := oddMultiples([]*Point{p}, 1<<(w-2))
+
+	for i := len(digits) - 1; i >= 0; i-- {
+		result.SetDouble(&result)
+
+		var a *affinePoint
+		switch d := digits[i]; {
+		case d > 0:
+			a = pos[0][d/2]
+
+		case d < 0:
+			a = neg[0][-d/2]
+		}
+
+		// The table holds nil for any multiple that is the point at
+		// infinity, which only happens for points of small order.
+		if a != nil {
+			result.addMixed(&result, a)
+		}
+	}
+
+	return z.Set(&result)
+}
+
+// wnaf returns the width-w non-adjacent form of c, least significant digit
+// first. Every digit is either zero or odd and less than 2^(w-1) in absolute
+// value, and any w consecutive digits hold at most one non-zero digit.
+func wnaf(c *big.Int, w uint) []int8 {
+	var (
+		k      = new(big.Int).Abs(c)
+		d      big.Int
+		digits = make([]int8, 0, k.BitLen()+1)
+		mask   = uint64(1)<<w - 1
+		half   = int64(1) << (w - 1)
+	)
+
+	for k.Sign() > 0 {
+		var digit int64
+		if k.Bit(0) == 1 {
+			// Take the signed residue of k modulo 2^w so that the
+			// next w-1 digits are zero.
+			digit = int64(uint64(k.Bits()[0]) & mask)
+			if digit >= half {
+				digit -= 1 << w
+			}
+
+			k.Sub(k, d.SetInt64(digit))
+		}
+
+		if c.Sign() < 0 {
+			digit = -digit
+		}
+
+		digits = append(digits, int8(digit))
+		k.Rsh(k, 1)
+	}
+
+	return digits
+}
+
+// oddMultiples returns, for each of the given points, the affine coordinates of
+// its first n odd multiples P, 3P, 5P, ... along with those of their negations.
+// Multiples that are the point at infinity are nil. The points must be on the
+// same curve.
+func oddMultiples(points []*Point, n int) ([][]*affinePoint,
+	[][]*affinePoint) {
+
+	multiples := make([]*Point, 0, len(points)*n)
+	for _, p := range points {
+		multiples = append(multiples, p.Copy())
+
+		if n > 1 {
+			double := new(Point).SetDouble(p)
+			for i := 1; i < n; i++ {
+				prev := multiples[len(multiples)-1]
+				multiples = append(
+					multiples, new(Point).SetAdd(prev, double),
+				)
+			}
+		}
+	}
+
+	// A single inversion is enough for all the tables.
+	batchToAffine(multiples)
+
+	pos := make([][]*affinePoint, len(points))
+	neg := make([][]*affinePoint, len(points))
+	for i := range points {
+		pos[i] = make([]*affinePoint, n)
+		neg[i] = make([]*affinePoint, n)
+
+		for j, m := range multiples[i*n : (i+1)*n] {
+			pos[i][j] = m.toAffine()
+			neg[i][j] = pos[i][j].negate()
+		}
+	}
+
+	return pos, neg
+}
+
+// negate returns the affine coordinates of the inverse of the point, or nil if
+// a is nil.
+func (a *affinePoint) negate() *affinePoint {
+	if a == nil {
+		return nil
+	}
+
+	return &affinePoint{
+		x: a.x,
+		y: new(finitefield.Element).SetNegate(a.y),
+	}
+}
@@ -0,0 +1,108 @@
+package ellipticcurve
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestWNAFDigits checks that the wNAF digits add back up to the scalar and
+// have the expected shape.
+func TestWNAFDigits(t *testing.T) {
+	max := new(big.Int).Lsh(big.NewInt(1), 256)
+
+	var vals []*big.Int
+	for i := int64(-20); i <= 20; i++ {
+		vals = append(vals, big.NewInt(i))
+	}
+	for i := 0; i < 10; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		require.NoError(t, err)
+
+		vals = append(vals, n, new(big.Int).Neg(n))
+	}
+
+	for w := uint(MinWindow); w <= MaxWindow; w++ {
+		bound := 1 << (w - 1)
+
+		for _, c := range vals {
+			digits := wnaf(c, w)
+
+			sum := new(big.Int)
+			for i := len(digits) - 1; i >= 0; i-- {
+				sum.Lsh(sum, 1)
+				sum.Add(sum, big.NewInt(int64(digits[i])))
+			}
+			require.Zero(t, c.Cmp(sum), "w=%d c=%d", w, c)
+
+			for i, d := range digits {
+				if d == 0 {
+					continue
+				}
+
+				require.Equal(t, int8(1), d&1)
+				require.Less(t, int(d), bound)
+				require.Greater(t, int(d), -bound)
+
+				// The next w-1 digits are all zero.
+				for j := i + 1; j < i+int(w) && j < len(digits); j++ {
+					require.Zero(t, digits[j])
+				}
+			}
+		}
+	}
+}
+
+// TestMulWNAF checks wNAF multiplication against double-and-add for every
+// window width, including on points whose small order puts the point at
+// infinity into the table of odd multiples.
+func TestMulWNAF(t *testing.T) {
+	points := []*Point{
+		(&testPoint{a: 0, b: 7, x: 47, y: 71}).ToPoint(t, 223),
+		(&testPoint{a: 0, b: 7, x: 192, y: 105}).ToPoint(t, 223),
+		(&testPoint{a: 5, b: 7, x: 2, y: 5}).ToPoint(t, 223),
+		(&testPoint{a: 0, b: 7, infinity: true}).ToPoint(t, 223),
+	}
+
+	for _, p := range points {
+		for w := uint(MinWindow); w <= MaxWindow; w++ {
+			for c := int64(-30); c <= 240; c++ {
+				exp, err := p.Mul(big.NewInt(c))
+				require.NoError(t, err)
+
+				// Double-and-add gives infinity for negative
+				// scalars so compare against |c|P negated.
+				if c < 0 {
+					exp, err = p.Mul(big.NewInt(-c))
+					require.NoError(t, err)
+
+					exp = negate(t, exp)
+				}
+
+				res, err := p.MulWNAF(big.NewInt(c), w)
+				require.NoError(t, err)
+				require.True(t, exp.Equal(res), "w=%d c=%d", w, c)
+			}
+		}
+	}
+
+	_, err := points[0].MulWNAF(big.NewInt(1), MinWindow-1)
+	require.ErrorIs(t, err, ErrInvalidWindow)
+
+	_, err = points[0].MulWNAF(big.NewInt(1), MaxWindow+1)
+	require.ErrorIs(t, err, ErrInvalidWindow)
+}
+
+// negate returns the inverse of the point.
+func negate(t *testing.T, p *Point) *Point {
+	if p.IsInfinity {
+		return p
+	}
+
+	n, err := NewPoint(p.X(), p.Y().Negate(), p.Curve)
+	require.NoError(t, err)
+
+	return n
+}
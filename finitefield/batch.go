@@ -0,0 +1,83 @@
+package finitefield
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNoInverse is returned when the multiplicative inverse of zero is
+// requested.
+var ErrNoInverse = errors.New("zero has no multiplicative inverse")
+
+// Inverse returns the multiplicative inverse of the Element. ErrNoInverse is
+// returned if the Element is zero.
+func (e *Element) Inverse() (*Element, error) {
+	if e.IsZero() {
+		return nil, ErrNoInverse
+	}
+
+	return new(Element).SetInverse(e), nil
+}
+
+// BatchInvert returns the multiplicative inverses of all the given Elements
+// using Montgomery's trick. Instead of one inversion per Element, only a single
+// inversion and 3(n-1) multiplications are needed to invert n Elements.
+//
+// All the Elements must be in the same finite field. If any of the Elements is
+// zero then an error wrapping ErrNoInverse that names the index of the first
+// zero Element is returned.
+func BatchInvert(elems []*Element) ([]*Element, error) {
+	if len(elems) == 0 {
+		return nil, nil
+	}
+
+	for i, e := range elems {
+		if !e.sameField(elems[0]) {
+			return nil, ErrElementsOfDifferentFields
+		}
+
+		if e.IsZero() {
+			return nil, fmt.Errorf("element %d: %w", i, ErrNoInverse)
+		}
+	}
+
+	// Compute the running products e_0, e_0*e_1, ..., e_0*...*e_(n-1).
+	var (
+		prods = make([]*Element, len(elems))
+		err   error
+	)
+	prods[0] = elems[0]
+	for i := 1; i < len(elems); i++ {
+		prods[i], err = prods[i-1].Mul(elems[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Invert the product of all the Elements. This is the only
+	// inversion.
+	inv, err := prods[len(prods)-1].Inverse()
+	if err != nil {
+		return nil, err
+	}
+
+	// Walk back through the Elements. At each step, inv is the inverse of
+	// e_0*...*e_i and so multiplying it by the product of the preceding
+	// Elements gives the inverse of e_i. Multiplying it by e_i then gives
+	// the inverse of e_0*...*e_(i-1) for the next step.
+	res := make([]*Element, len(elems))
+	for i := len(elems) - 1; i > 0; i-- {
+		res[i], err = inv.Mul(prods[i-1])
+		if err != nil {
+			return nil, err
+		}
+
+		inv, err = inv.Mul(elems[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	res[0] = inv
+
+	return res, nil
+}
@@ -0,0 +1,89 @@
+package finitefield
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestInverse tests the Inverse method of Element.
+func TestInverse(t *testing.T) {
+	prime := big.NewInt(223)
+
+	for i := int64(1); i < 223; i++ {
+		e, err := NewElement(big.NewInt(i), prime)
+		require.NoError(t, err)
+
+		inv, err := e.Inverse()
+		require.NoError(t, err)
+
+		res, err := e.Mul(inv)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(1), res.Num)
+	}
+
+	zero, err := NewElement(big.NewInt(0), prime)
+	require.NoError(t, err)
+
+	_, err = zero.Inverse()
+	require.ErrorIs(t, err, ErrNoInverse)
+}
+
+// TestBatchInvert checks that BatchInvert produces the same results as
+// inverting each Element individually.
+func TestBatchInvert(t *testing.T) {
+	p, ok := new(big.Int).SetString(
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141",
+		16,
+	)
+	require.True(t, ok)
+
+	for _, n := range []int{1, 2, 3, 10, 64} {
+		elems := make([]*Element, n)
+		for i := range elems {
+			num, err := rand.Int(rand.Reader, new(big.Int).Sub(p, one))
+			require.NoError(t, err)
+
+			elems[i], err = NewElement(num.Add(num, one), p)
+			require.NoError(t, err)
+		}
+
+		invs, err := BatchInvert(elems)
+		require.NoError(t, err)
+		require.Len(t, invs, n)
+
+		for i, e := range elems {
+			exp, err := e.Inverse()
+			require.NoError(t, err)
+			require.True(t, exp.Equal(invs[i]))
+		}
+	}
+
+	invs, err := BatchInvert(nil)
+	require.NoError(t, err)
+	require.Empty(t, invs)
+}
+
+// TestBatchInvertErrors checks that BatchInvert rejects zero Elements and
+// Elements from different fields.
+func TestBatchInvertErrors(t *testing.T) {
+	var elems []*Element
+	for _, n := range []int64{3, 5, 0, 7} {
+		e, err := NewElement(big.NewInt(n), big.NewInt(19))
+		require.NoError(t, err)
+
+		elems = append(elems, e)
+	}
+
+	_, err := BatchInvert(elems)
+	require.ErrorIs(t, err, ErrNoInverse)
+	require.ErrorContains(t, err, "element 2")
+
+	other, err := NewElement(big.NewInt(3), big.NewInt(11))
+	require.NoError(t, err)
+
+	_, err = BatchInvert([]*Element{elems[0], other})
+	require.ErrorIs(t, err, ErrElementsOfDifferentFields)
+}
@@ -24,8 +24,15 @@ type Element struct {
 	Num *big.Int
 
 	// P is the order of the finite field in which the Element's Num is
-	// defined.
+	// defined. It is shared by all the Elements of the same field, which
+	// makes comparing the fields of two Elements cheap, and so must never
+	// be modified.
 	P *big.Int
+
+	// m is the modulus of the Field that the Element comes from. It holds
+	// precomputed values for P, such as its Inverter, and is nil for
+	// Elements that were not created through a Field.
+	m *modulus
 }
 
 // NewElement constructs a new Element.
@@ -53,7 +60,7 @@ func (e *Element) String() string {
 // Equal returns true if the passed Element is equivalent to this Element.
 func (e *Element) Equal(o *Element) bool {
 	// Elements in different finite fields are not equal.
-	if e.P.Cmp(o.P) != 0 {
+	if !e.sameField(o) {
 		return false
 	}
 
@@ -62,104 +69,69 @@ func (e *Element) Equal(o *Element) bool {
 
 // Add adds two Elements in the same finite field together.
 func (e *Element) Add(o *Element) (*Element, error) {
-	if e.P.Cmp(o.P) != 0 {
+	if !e.sameField(o) {
 		return nil, ErrElementsOfDifferentFields
 	}
 
-	var res big.Int
-	res.Add(e.Num, o.Num)
-	res.Mod(&res, e.P)
-
-	return &Element{
-		Num: &res,
-		P:   e.P,
-	}, nil
+	return new(Element).SetAdd(e, o), nil
 }
 
 // Sub subtracts the given Element from this Element.
 func (e *Element) Sub(o *Element) (*Element, error) {
-	if e.P.Cmp(o.P) != 0 {
+	if !e.sameField(o) {
 		return nil, ErrElementsOfDifferentFields
 	}
 
-	var res big.Int
-	res.Sub(e.Num, o.Num)
-	res.Mod(&res, e.P)
-
-	if res.Sign() < 0 {
-		res.Add(&res, e.P)
-	}
-
-	return &Element{
-		Num: &res,
-		P:   e.P,
-	}, nil
+	return new(Element).SetSub(e, o), nil
 }
 
 // Mul multiplies the two Elements together.
 func (e *Element) Mul(o *Element) (*Element, error) {
-	if e.P.Cmp(o.P) != 0 {
+	if !e.sameField(o) {
 		return nil, ErrElementsOfDifferentFields
 	}
 
-	var res big.Int
-	res.Mul(e.Num, o.Num)
-	res.Mod(&res, e.P)
-
-	if res.Sign() < 0 {
-		res.Add(&res, e.P)
-	}
-
-	return &Element{
-		Num: &res,
-		P:   e.P,
-	}, nil
+	return new(Element).SetMul(e, o), nil
 }
 
 // Pow defines exponentiation on the Element.
 func (e *Element) Pow(exp *big.Int) *Element {
-	var p big.Int
-	p.Sub(e.P, one)
-
-	var n big.Int
-	n.Mod(exp, &p)
-
-	if n.Sign() < 0 {
-		n.Add(&n, &p)
-	}
-
-	var res big.Int
-	res.Exp(e.Num, &n, e.P)
-
-	if res.Sign() < 0 {
-		res.Add(&res, e.P)
-	}
-
-	return &Element{
-		Num: &res,
-		P:   e.P,
-	}
+	return new(Element).SetPow(e, exp)
 }
 
 // Div divides this Element by the given Element and returns the resulting
 // Element.
 func (e *Element) Div(o *Element) (*Element, error) {
-	if e.P.Cmp(o.P) != 0 {
+	if !e.sameField(o) {
 		return nil, ErrElementsOfDifferentFields
 	}
 
-	var exp big.Int
-	exp.Sub(e.P, two)
-
-	n2, err := e.Mul(o.Pow(&exp))
-	if err != nil {
-		return nil, err
-	}
+	return new(Element).SetDiv(e, o), nil
+}
 
-	return n2, nil
+// Negate returns the additive inverse of the Element.
+func (e *Element) Negate() *Element {
+	return new(Element).SetNegate(e)
 }
 
 // IsZero returns true if the Element's number is zero.
 func (e *Element) IsZero() bool {
 	return e.Num.Sign() == 0
 }
+
+// newElement constructs a new Element in the same finite field as this Element.
+// The given number must already be reduced.
+func (e *Element) newElement(n *big.Int) *Element {
+	return &Element{
+		Num: n,
+		P:   e.P,
+		m:   e.m,
+	}
+}
+
+// sameField returns true if the two Elements are in the same finite field.
+func (e *Element) sameField(o *Element) bool {
+	// Elements handed out by a Field share the same order so the pointer
+	// comparison is usually enough.
+	return e.P == o.P || e.P.Cmp(o.P) == 0
+}
@@ -0,0 +1,166 @@
+package finitefield
+
+import (
+	"encoding"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var (
+	// ErrInvalidEncoding is returned when an encoded Element can not be
+	// decoded, either because it has the wrong length or because it is
+	// not the canonical encoding of an Element.
+	ErrInvalidEncoding = errors.New("invalid element encoding")
+
+	// ErrNoField is returned when decoding into an Element that does not
+	// yet know which field it is in.
+	ErrNoField = errors.New("the field of the element must be set " +
+		"before decoding into it")
+)
+
+// Compile time checks to ensure that Element implements the encoding
+// interfaces.
+var (
+	_ encoding.BinaryMarshaler   = (*Element)(nil)
+	_ encoding.BinaryUnmarshaler = (*Element)(nil)
+	_ encoding.TextMarshaler     = (*Element)(nil)
+	_ encoding.TextUnmarshaler   = (*Element)(nil)
+	_ json.Marshaler             = (*Element)(nil)
+	_ json.Unmarshaler           = (*Element)(nil)
+)
+
+// ByteLen returns the number of bytes in the fixed-width encoding of the
+// Element. This is the number of bytes needed to hold the order of its field.
+func (e *Element) ByteLen() int {
+	return (e.P.BitLen() + 7) / 8
+}
+
+// Bytes returns the fixed-width big-endian encoding of the Element.
+func (e *Element) Bytes() []byte {
+	return e.Num.FillBytes(make([]byte, e.ByteLen()))
+}
+
+// LittleEndianBytes returns the fixed-width little-endian encoding of the
+// Element.
+func (e *Element) LittleEndianBytes() []byte {
+	return reverse(e.Bytes())
+}
+
+// FromLittleEndianBytes constructs a new Element of the Field from its
+// fixed-width little-endian encoding. The encoding must be exactly ByteLen
+// bytes long and must hold a value less than the order of the Field.
+func (f *Field) FromLittleEndianBytes(b []byte) (*Element, error) {
+	return f.FromBytes(reverse(b))
+}
+
+// MarshalBinary returns the fixed-width big-endian encoding of the Element.
+//
+// NOTE: this is part of the encoding.BinaryMarshaler interface.
+func (e *Element) MarshalBinary() ([]byte, error) {
+	return e.Bytes(), nil
+}
+
+// UnmarshalBinary sets the Element to the value of its fixed-width big-endian
+// encoding. Since the encoding does not include the order of the field, P must
+// already be set, for example by starting from Field.Zero. Encodings of values
+// that are not less than P are rejected.
+//
+// NOTE: this is part of the encoding.BinaryUnmarshaler interface.
+func (e *Element) UnmarshalBinary(b []byte) error {
+	if e.P == nil {
+		return ErrNoField
+	}
+
+	n, err := decodeElement(b, e.P)
+	if err != nil {
+		return err
+	}
+
+	e.Num = n
+
+	return nil
+}
+
+// MarshalText returns the hex encoding of the fixed-width big-endian encoding
+// of the Element.
+//
+// NOTE: this is part of the encoding.TextMarshaler interface.
+func (e *Element) MarshalText() ([]byte, error) {
+	b := e.Bytes()
+
+	text := make([]byte, hex.EncodedLen(len(b)))
+	hex.Encode(text, b)
+
+	return text, nil
+}
+
+// UnmarshalText sets the Element to the value of the hex encoding produced by
+// MarshalText. Like UnmarshalBinary, P must already be set.
+//
+// NOTE: this is part of the encoding.TextUnmarshaler interface.
+func (e *Element) UnmarshalText(text []byte) error {
+	b := make([]byte, hex.DecodedLen(len(text)))
+	if _, err := hex.Decode(b, text); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEncoding, err)
+	}
+
+	return e.UnmarshalBinary(b)
+}
+
+// MarshalJSON encodes the Element as a JSON string holding the same hex
+// encoding as MarshalText.
+//
+// NOTE: this is part of the json.Marshaler interface.
+func (e *Element) MarshalJSON() ([]byte, error) {
+	text, err := e.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON sets the Element to the value of the JSON string produced by
+// MarshalJSON. Like UnmarshalBinary, P must already be set.
+//
+// NOTE: this is part of the json.Unmarshaler interface.
+func (e *Element) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEncoding, err)
+	}
+
+	return e.UnmarshalText([]byte(text))
+}
+
+// decodeElement decodes the fixed-width big-endian encoding of an element of
+// the field of order p.
+func decodeElement(b []byte, p *big.Int) (*big.Int, error) {
+	byteLen := (p.BitLen() + 7) / 8
+	if len(b) != byteLen {
+		return nil, fmt.Errorf("%w: an element of the field of order "+
+			"%s must be encoded in %d bytes, got %d",
+			ErrInvalidEncoding, p, byteLen, len(b))
+	}
+
+	n := new(big.Int).SetBytes(b)
+	if n.Cmp(p) >= 0 {
+		return nil, fmt.Errorf("%w: value is not less than the order "+
+			"%s", ErrInvalidEncoding, p)
+	}
+
+	return n, nil
+}
+
+// reverse returns a reversed copy of b.
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+
+	return r
+}
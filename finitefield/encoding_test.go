@@ -0,0 +1,88 @@
+package finitefield
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestElementEncoding tests the fixed-width encodings of Element.
+func TestElementEncoding(t *testing.T) {
+	f, err := NewField(big.NewInt(65521))
+	require.NoError(t, err)
+
+	e := f.FromInt(0x0102)
+	require.Equal(t, 2, e.ByteLen())
+	require.Equal(t, []byte{0x01, 0x02}, e.Bytes())
+	require.Equal(t, []byte{0x02, 0x01}, e.LittleEndianBytes())
+	require.Equal(t, []byte{0x00, 0x00}, f.Zero().Bytes())
+
+	e2, err := f.FromLittleEndianBytes([]byte{0x02, 0x01})
+	require.NoError(t, err)
+	require.True(t, e.Equal(e2))
+
+	// Values that are not less than the order are rejected in both byte
+	// orders.
+	_, err = f.FromBytes([]byte{0xff, 0xf1})
+	require.ErrorIs(t, err, ErrInvalidEncoding)
+
+	_, err = f.FromLittleEndianBytes([]byte{0xf1, 0xff})
+	require.ErrorIs(t, err, ErrInvalidEncoding)
+
+	_, err = f.FromBytes([]byte{0x01})
+	require.ErrorIs(t, err, ErrInvalidEncoding)
+}
+
+// TestElementMarshal checks that an Element survives a round trip through each
+// of the encoding interfaces and that invalid encodings are rejected.
+func TestElementMarshal(t *testing.T) {
+	f, err := NewField(big.NewInt(223))
+	require.NoError(t, err)
+
+	e := f.FromInt(171)
+
+	b, err := e.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xab}, b)
+
+	dec := f.Zero()
+	require.NoError(t, dec.UnmarshalBinary(b))
+	require.True(t, e.Equal(dec))
+
+	text, err := e.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "ab", string(text))
+
+	dec = f.Zero()
+	require.NoError(t, dec.UnmarshalText(text))
+	require.True(t, e.Equal(dec))
+
+	// Elements embedded in other types are encoded as hex strings.
+	type wrapper struct {
+		E *Element `json:"e"`
+	}
+
+	j, err := json.Marshal(wrapper{E: e})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"e": "ab"}`, string(j))
+
+	w := wrapper{E: f.Zero()}
+	require.NoError(t, json.Unmarshal(j, &w))
+	require.True(t, e.Equal(w.E))
+
+	// The field must be known before decoding.
+	require.ErrorIs(t, new(Element).UnmarshalBinary(b), ErrNoField)
+
+	// 0xdf = 223 is not canonical.
+	require.ErrorIs(
+		t, f.Zero().UnmarshalBinary([]byte{0xdf}), ErrInvalidEncoding,
+	)
+	require.ErrorIs(
+		t, f.Zero().UnmarshalText([]byte("xx")), ErrInvalidEncoding,
+	)
+	require.ErrorIs(
+		t, f.Zero().UnmarshalJSON([]byte("171")), ErrInvalidEncoding,
+	)
+}
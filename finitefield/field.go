@@ -0,0 +1,170 @@
+package finitefield
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// ErrNotPrime is returned when a Field is requested for an order that is not
+// prime.
+var ErrNotPrime = errors.New("the order of the field must be prime")
+
+// Field is a finite field of prime order. A Field keeps a private copy of the
+// order that it was created with. All the Elements that it creates share a
+// second copy of the order as their P which, like the P of any Element, must
+// never be modified. Doing so corrupts those Elements but not the Field itself.
+type Field struct {
+	p *big.Int
+	m *modulus
+}
+
+// NewField constructs a new Field of the given order which must be prime.
+func NewField(p *big.Int) (*Field, error) {
+	if p.Sign() <= 0 || !p.ProbablyPrime(20) {
+		return nil, ErrNotPrime
+	}
+
+	m := newModulus(new(big.Int).Set(p))
+
+	return &Field{
+		p: m.p,
+		m: m,
+	}, nil
+}
+
+// Modulus returns a copy of the order of the Field.
+func (f *Field) Modulus() *big.Int {
+	return new(big.Int).Set(f.p)
+}
+
+// BitLen returns the number of bits needed to represent the order of the
+// Field.
+func (f *Field) BitLen() int {
+	return f.p.BitLen()
+}
+
+// ByteLen returns the number of bytes needed to encode an Element of the
+// Field.
+func (f *Field) ByteLen() int {
+	return f.m.byteLen
+}
+
+// String returns a string representation of the Field.
+func (f *Field) String() string {
+	return fmt.Sprintf("Field_%s", f.p)
+}
+
+// Equal returns true if the two Fields have the same order.
+func (f *Field) Equal(o *Field) bool {
+	return f == o || f.p.Cmp(o.p) == 0
+}
+
+// Contains returns true if the Element is in the Field.
+func (f *Field) Contains(e *Element) bool {
+	return e.P == f.m.elemP || e.P.Cmp(f.p) == 0
+}
+
+// ContainsSecret returns true if the SecretElement is in the Field.
+func (f *Field) ContainsSecret(e *SecretElement) bool {
+	return e.m == f.m || e.m.p.Cmp(f.p) == 0
+}
+
+// NewElement constructs a new Element of the Field. The number must be in the
+// range [0, p).
+func (f *Field) NewElement(n *big.Int) (*Element, error) {
+	e, err := NewElement(new(big.Int).Set(n), f.m.elemP)
+	if err != nil {
+		return nil, err
+	}
+	e.m = f.m
+
+	return e, nil
+}
+
+// FromInt returns the Element of the Field that is congruent to n. Unlike
+// NewElement, n may be negative or larger than the order of the Field.
+func (f *Field) FromInt(n int64) *Element {
+	return f.FromBigInt(big.NewInt(n))
+}
+
+// FromBigInt returns the Element of the Field that is congruent to n. Unlike
+// NewElement, n may be negative or larger than the order of the Field.
+func (f *Field) FromBigInt(n *big.Int) *Element {
+	return f.element(new(big.Int).Mod(n, f.p))
+}
+
+// FromBytes constructs a new Element of the Field from its fixed-width
+// big-endian encoding. The encoding must be exactly ByteLen bytes long and must
+// hold a value less than the order of the Field.
+func (f *Field) FromBytes(b []byte) (*Element, error) {
+	n, err := decodeElement(b, f.p)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.element(n), nil
+}
+
+// Random returns a uniformly random Element of the Field drawn from the given
+// source of randomness.
+func (f *Field) Random(rand io.Reader) (*Element, error) {
+	return randElement(rand, f.m.elemP, f.m)
+}
+
+// Zero returns the additive identity of the Field.
+func (f *Field) Zero() *Element {
+	return f.element(new(big.Int))
+}
+
+// One returns the multiplicative identity of the Field.
+func (f *Field) One() *Element {
+	return f.element(big.NewInt(1))
+}
+
+// SecretFromBytes returns a SecretElement of the Field from the big-endian
+// byte slice reduced modulo the order of the Field. The reduction runs in time
+// that only depends on the length of b.
+func (f *Field) SecretFromBytes(b []byte) *SecretElement {
+	return f.m.fromBytes(b)
+}
+
+// Montgomery returns the Montgomery context of the Field. It is nil for the
+// field of order 2.
+func (f *Field) Montgomery() *Montgomery {
+	return f.m.mont
+}
+
+// element wraps the reduced number n in an Element of the Field. The Element
+// shares the order with the other Elements of the Field.
+func (f *Field) element(n *big.Int) *Element {
+	return &Element{
+		Num: n,
+		P:   f.m.elemP,
+		m:   f.m,
+	}
+}
+
+// randElement returns a uniformly random Element of the field of order p. The
+// Element refers to p itself and to m, which may be nil.
+func randElement(rand io.Reader, p *big.Int, m *modulus) (*Element, error) {
+	b := make([]byte, (p.BitLen()+7)/8)
+	excess := len(b)*8 - p.BitLen()
+
+	for {
+		if _, err := io.ReadFull(rand, b); err != nil {
+			return nil, err
+		}
+		b[0] &= 0xff >> excess
+
+		n := new(big.Int).SetBytes(b)
+		if n.Cmp(p) < 0 {
+			return &Element{
+				Num: n,
+				P:   p,
+				m:   m,
+			}, nil
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package finitefield
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewField checks that only prime orders are accepted and that the Field
+// keeps its order to itself.
+func TestNewField(t *testing.T) {
+	for _, p := range []int64{-7, 0, 1, 4, 15, 221} {
+		_, err := NewField(big.NewInt(p))
+		require.ErrorIs(t, err, ErrNotPrime)
+	}
+
+	p := big.NewInt(223)
+	f, err := NewField(p)
+	require.NoError(t, err)
+	require.Equal(t, "Field_223", f.String())
+	require.Equal(t, 8, f.BitLen())
+	require.Equal(t, 1, f.ByteLen())
+
+	// Modifying the order that the Field was created with, or the copy
+	// that it hands out, does not affect the Field.
+	p.SetInt64(19)
+	f.Modulus().SetInt64(19)
+	require.Equal(t, big.NewInt(223), f.Modulus())
+
+	f2, err := NewField(big.NewInt(223))
+	require.NoError(t, err)
+	require.True(t, f.Equal(f2))
+
+	f3, err := NewField(big.NewInt(19))
+	require.NoError(t, err)
+	require.False(t, f.Equal(f3))
+}
+
+// TestFieldElementsShareOrder checks that the Elements handed out by a Field
+// share their order but that modifying it does not change the Field itself.
+func TestFieldElementsShareOrder(t *testing.T) {
+	f, err := NewField(big.NewInt(229))
+	require.NoError(t, err)
+
+	random, err := f.Random(rand.Reader)
+	require.NoError(t, err)
+
+	fromBytes, err := f.FromBytes([]byte{5})
+	require.NoError(t, err)
+
+	newElement, err := f.NewElement(big.NewInt(5))
+	require.NoError(t, err)
+
+	elements := []*Element{
+		f.Zero(), f.One(), f.FromInt(5), f.FromBigInt(big.NewInt(-5)),
+		random, fromBytes, newElement,
+		f.SecretFromBytes([]byte{5}).Element(),
+		f.Montgomery().One().FromMont(),
+	}
+	for _, e := range elements {
+		require.Same(t, elements[0].P, e.P)
+	}
+
+	elements[0].P.SetInt64(19)
+
+	require.Equal(t, big.NewInt(229), f.Modulus())
+	require.Equal(t, int64(71), f.FromInt(300).Num.Int64())
+	require.Equal(
+		t, []byte{2}, f.SecretFromBytes([]byte{231}).Bytes(),
+	)
+}
+
+// TestFieldElements tests the constructors of Field.
+func TestFieldElements(t *testing.T) {
+	f, err := NewField(big.NewInt(223))
+	require.NoError(t, err)
+
+	require.True(t, f.Zero().IsZero())
+	require.Equal(t, big.NewInt(1), f.One().Num)
+	require.Equal(t, big.NewInt(222), f.FromInt(-1).Num)
+	require.Equal(t, big.NewInt(2), f.FromInt(225).Num)
+	require.Equal(t, big.NewInt(2), f.FromBigInt(big.NewInt(-221)).Num)
+
+	e, err := f.NewElement(big.NewInt(105))
+	require.NoError(t, err)
+	require.True(t, f.Contains(e))
+
+	_, err = f.NewElement(big.NewInt(223))
+	require.Error(t, err)
+
+	// Elements of the Field can be mixed with Elements that were made
+	// without it.
+	e2, err := NewElement(big.NewInt(105), big.NewInt(223))
+	require.NoError(t, err)
+	require.True(t, e.Equal(e2))
+	require.True(t, f.Contains(e2))
+
+	sum, err := e.Add(f.One())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(106), sum.Num)
+
+	other, err := NewField(big.NewInt(19))
+	require.NoError(t, err)
+	require.False(t, other.Contains(e))
+
+	_, err = e.Add(other.One())
+	require.ErrorIs(t, err, ErrElementsOfDifferentFields)
+}
+
+// TestFieldFromBytes checks that FromBytes only accepts canonical encodings.
+func TestFieldFromBytes(t *testing.T) {
+	f, err := NewField(big.NewInt(65521))
+	require.NoError(t, err)
+
+	e, err := f.FromBytes([]byte{0xff, 0xf0})
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(65520), e.Num)
+
+	_, err = f.FromBytes([]byte{0xff, 0xf1})
+	require.Error(t, err)
+
+	_, err = f.FromBytes([]byte{0x01})
+	require.Error(t, err)
+
+	_, err = f.FromBytes([]byte{0x00, 0x00, 0x01})
+	require.Error(t, err)
+}
+
+// TestFieldRandom checks that Random only produces Elements of the Field and
+// that it uses the given source of randomness.
+func TestFieldRandom(t *testing.T) {
+	f, err := NewField(big.NewInt(65521))
+	require.NoError(t, err)
+
+	// The first two byte pairs are not less than the order and so must be
+	// skipped.
+	src := bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xf1, 0x01, 0x02})
+
+	e, err := f.Random(src)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(0x0102), e.Num)
+
+	_, err = f.Random(src)
+	require.Error(t, err)
+}
+
+// TestFieldSecretFromBytes checks that SecretFromBytes reduces its input.
+func TestFieldSecretFromBytes(t *testing.T) {
+	f, err := NewField(big.NewInt(223))
+	require.NoError(t, err)
+
+	s := f.SecretFromBytes([]byte{0x01, 0x00})
+	require.Equal(t, big.NewInt(256-223), s.Element().Num)
+	require.NotNil(t, f.Montgomery())
+}
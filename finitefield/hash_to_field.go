@@ -0,0 +1,118 @@
+package finitefield
+
+import (
+	"errors"
+	"hash"
+	"math/big"
+)
+
+// The functions in this file implement expand_message_xmd and hash_to_field
+// from RFC 9380, "Hashing to Elliptic Curves"
+// (https://www.rfc-editor.org/rfc/rfc9380.html), sections 5.2 and 5.3.1.
+
+// oversizeDSTPrefix is prepended to domain separation tags that are longer than
+// 255 bytes before they are hashed down to size.
+const oversizeDSTPrefix = "H2C-OVERSIZE-DST-"
+
+var (
+	// ErrExpandLength is returned when ExpandMessageXMD is asked for more
+	// bytes than it can produce.
+	ErrExpandLength = errors.New("requested length is too long for " +
+		"expand_message_xmd")
+
+	// ErrEmptyDST is returned when the domain separation tag is empty.
+	ErrEmptyDST = errors.New("domain separation tag must not be empty")
+)
+
+// ExpandMessageXMD expands msg into n uniformly random bytes using the hash
+// function h, as described in section 5.3.1 of RFC 9380. The domain separation
+// tag dst must not be empty and is hashed first if it is longer than 255
+// bytes. ErrExpandLength is returned if n is larger than 255 hash outputs or
+// 65535 bytes.
+func ExpandMessageXMD(h func() hash.Hash, msg, dst []byte, n int) ([]byte,
+	error) {
+
+	if len(dst) == 0 {
+		return nil, ErrEmptyDST
+	}
+
+	hf := h()
+	bSize, rSize := hf.Size(), hf.BlockSize()
+
+	ell := (n + bSize - 1) / bSize
+	if n <= 0 || ell > 255 || n > 65535 {
+		return nil, ErrExpandLength
+	}
+
+	if len(dst) > 255 {
+		hf.Write([]byte(oversizeDSTPrefix))
+		hf.Write(dst)
+		dst = hf.Sum(nil)
+	}
+
+	// dstPrime = dst || I2OSP(len(dst), 1)
+	dstPrime := make([]byte, 0, len(dst)+1)
+	dstPrime = append(dstPrime, dst...)
+	dstPrime = append(dstPrime, byte(len(dst)))
+
+	// b0 = H(Z_pad || msg || I2OSP(n, 2) || I2OSP(0, 1) || dstPrime)
+	hf.Reset()
+	hf.Write(make([]byte, rSize))
+	hf.Write(msg)
+	hf.Write([]byte{byte(n >> 8), byte(n), 0})
+	hf.Write(dstPrime)
+	b0 := hf.Sum(nil)
+
+	// b1 = H(b0 || I2OSP(1, 1) || dstPrime)
+	hf.Reset()
+	hf.Write(b0)
+	hf.Write([]byte{1})
+	hf.Write(dstPrime)
+	bi := hf.Sum(nil)
+
+	out := make([]byte, 0, ell*bSize)
+	out = append(out, bi...)
+
+	// bi = H(strxor(b0, b(i-1)) || I2OSP(i, 1) || dstPrime)
+	x := make([]byte, bSize)
+	for i := 2; i <= ell; i++ {
+		for j := range x {
+			x[j] = b0[j] ^ bi[j]
+		}
+
+		hf.Reset()
+		hf.Write(x)
+		hf.Write([]byte{byte(i)})
+		hf.Write(dstPrime)
+		bi = hf.Sum(bi[:0])
+
+		out = append(out, bi...)
+	}
+
+	return out[:n], nil
+}
+
+// HashToField hashes msg to count Elements of the Field, as described in
+// section 5.2 of RFC 9380. The bytes are produced by ExpandMessageXMD with the
+// hash function h and the domain separation tag dst. k is the target security
+// level in bits, which sets how many extra bytes are reduced into each Element
+// so that the result is close to uniform.
+func (f *Field) HashToField(h func() hash.Hash, msg, dst []byte, count,
+	k int) ([]*Element, error) {
+
+	// L = ceil((ceil(log2(p)) + k) / 8)
+	l := (f.p.BitLen() + k + 7) / 8
+
+	b, err := ExpandMessageXMD(h, msg, dst, count*l)
+	if err != nil {
+		return nil, err
+	}
+
+	elements := make([]*Element, count)
+	for i := range elements {
+		n := new(big.Int).SetBytes(b[i*l : (i+1)*l])
+		elements[i] = f.FromBigInt(n)
+	}
+
+	return elements, nil
+}
@@ -0,0 +1,106 @@
+package finitefield
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestExpandMessageXMD checks ExpandMessageXMD against the SHA-256 test vectors
+// of appendix K.1 of RFC 9380.
+func TestExpandMessageXMD(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	longDST := []byte("QUUX-V01-CS02-with-expander-SHA256-128-long-DST-" +
+		strings.Repeat("1", 200))
+
+	tests := []struct {
+		name string
+		msg  string
+		dst  []byte
+		n    int
+		out  string
+	}{
+		{
+			name: "empty msg",
+			msg:  "",
+			dst:  dst,
+			n:    0x20,
+			out: "68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a" +
+				"21d803f07235",
+		},
+		{
+			name: "abc",
+			msg:  "abc",
+			dst:  dst,
+			n:    0x20,
+			out: "d8ccab23b5985ccea865c6c97b6e5b8350e794e603b4b97902f5" +
+				"3a8a0d605615",
+		},
+		{
+			name: "several blocks",
+			msg:  "",
+			dst:  dst,
+			n:    0x80,
+			out: "af84c27ccfd45d41914fdff5df25293e221afc53d8ad2ac06d5e" +
+				"3e29485dadbee0d121587713a3e0dd4d5e69e93eb7cd4f5df4cd" +
+				"103e188cf60cb02edc3edf18eda8576c412b18ffb658e3dd6ec8" +
+				"49469b979d444cf7b26911a08e63cf31f9dcc541708d34911844" +
+				"72c2c29bb749d4286b004ceb5ee6b9a7fa5b646c993f0ced",
+		},
+		{
+			name: "long dst",
+			msg:  "abc",
+			dst:  longDST,
+			n:    0x20,
+			out: "c1f904ee362795355c9c5b09095611cc30a20c64fd3dfbeb8faf" +
+				"9be1310c30b9",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			out, err := ExpandMessageXMD(
+				sha256.New, []byte(test.msg), test.dst, test.n,
+			)
+			require.NoError(t, err)
+			require.Equal(t, test.out, hex.EncodeToString(out))
+		})
+	}
+
+	_, err := ExpandMessageXMD(sha256.New, nil, dst, 255*32+1)
+	require.ErrorIs(t, err, ErrExpandLength)
+
+	_, err = ExpandMessageXMD(sha256.New, nil, dst, 0)
+	require.ErrorIs(t, err, ErrExpandLength)
+
+	_, err = ExpandMessageXMD(sha256.New, nil, nil, 32)
+	require.ErrorIs(t, err, ErrEmptyDST)
+}
+
+// TestHashToField checks that HashToField reduces the expanded bytes into the
+// field and that the Elements are independent.
+func TestHashToField(t *testing.T) {
+	f, err := NewField(big.NewInt(223))
+	require.NoError(t, err)
+
+	dst := []byte("test")
+	elements, err := f.HashToField(sha256.New, []byte("msg"), dst, 3, 128)
+	require.NoError(t, err)
+	require.Len(t, elements, 3)
+
+	// L = ceil((8 + 128) / 8) = 17 bytes are used for each Element.
+	b, err := ExpandMessageXMD(sha256.New, []byte("msg"), dst, 3*17)
+	require.NoError(t, err)
+
+	for i, e := range elements {
+		require.True(t, f.Contains(e))
+
+		n := new(big.Int).SetBytes(b[i*17 : (i+1)*17])
+		require.Zero(t, n.Mod(n, big.NewInt(223)).Cmp(e.Num))
+	}
+}
@@ -0,0 +1,170 @@
+package finitefield
+
+import (
+	"math/big"
+	"sync"
+)
+
+// The methods in this file are in-place variants of the Element arithmetic in
+// the style of math/big: z.SetAdd(x, y) sets z to x + y and returns z. Unlike
+// the methods that return a new Element, they reuse the storage of z so that
+// hot loops do not allocate a new Element, and a new big.Int, per operation.
+//
+// The receiver may alias any of the operands. The operands must be in the same
+// finite field and, since there is no error to return, the methods panic with
+// ErrElementsOfDifferentFields if they are not. The result is written into the
+// existing z.Num, so z must not share its Num with any other Element.
+
+// intPool holds scratch big.Ints used for the intermediate values of the
+// in-place arithmetic. Reusing them means that, once their backing arrays have
+// grown large enough, the arithmetic does not allocate.
+var intPool = sync.Pool{
+	New: func() interface{} {
+		return new(big.Int)
+	},
+}
+
+// Set sets z to x and returns z.
+func (z *Element) Set(x *Element) *Element {
+	z.num().Set(x.Num)
+	z.P = x.P
+	z.m = x.m
+
+	return z
+}
+
+// SetAdd sets z to x + y and returns z.
+func (z *Element) SetAdd(x, y *Element) *Element {
+	x.mustSameField(y)
+
+	n := z.num()
+	n.Add(x.Num, y.Num)
+
+	// Both operands are less than p so one subtraction is enough to reduce
+	// the sum.
+	if n.Cmp(x.P) >= 0 {
+		n.Sub(n, x.P)
+	}
+	z.P = x.P
+	z.m = x.m
+
+	return z
+}
+
+// SetSub sets z to x - y and returns z.
+func (z *Element) SetSub(x, y *Element) *Element {
+	x.mustSameField(y)
+
+	n := z.num()
+	n.Sub(x.Num, y.Num)
+
+	if n.Sign() < 0 {
+		n.Add(n, x.P)
+	}
+	z.P = x.P
+	z.m = x.m
+
+	return z
+}
+
+// SetMul sets z to x * y and returns z.
+func (z *Element) SetMul(x, y *Element) *Element {
+	x.mustSameField(y)
+
+	prod := intPool.Get().(*big.Int)
+	prod.Mul(x.Num, y.Num)
+	z.reduce(prod, x)
+	intPool.Put(prod)
+
+	return z
+}
+
+// SetSquare sets z to x * x and returns z.
+func (z *Element) SetSquare(x *Element) *Element {
+	return z.SetMul(x, x)
+}
+
+// SetNegate sets z to -x and returns z.
+func (z *Element) SetNegate(x *Element) *Element {
+	n := z.num()
+	if x.Num.Sign() == 0 {
+		n.SetInt64(0)
+	} else {
+		n.Sub(x.P, x.Num)
+	}
+	z.P = x.P
+	z.m = x.m
+
+	return z
+}
+
+// SetPow sets z to x raised to the given exponent and returns z. Like Pow, the
+// exponent is reduced modulo p-1 so it may be negative.
+func (z *Element) SetPow(x *Element, exp *big.Int) *Element {
+	order := intPool.Get().(*big.Int)
+	order.Sub(x.P, one)
+
+	n := intPool.Get().(*big.Int)
+	n.Mod(exp, order)
+
+	z.num().Exp(x.Num, n, x.P)
+	z.P = x.P
+	z.m = x.m
+
+	intPool.Put(order)
+	intPool.Put(n)
+
+	return z
+}
+
+// SetDiv sets z to x / y and returns z. Like Div, dividing by zero gives zero.
+func (z *Element) SetDiv(x, y *Element) *Element {
+	x.mustSameField(y)
+
+	inv := intPool.Get().(*big.Int)
+	invertVartime(inv, y.Num, x)
+
+	inv.Mul(inv, x.Num)
+	z.reduce(inv, x)
+	intPool.Put(inv)
+
+	return z
+}
+
+// SetInverse sets z to the multiplicative inverse of x and returns z. The
+// inverse of zero is zero.
+func (z *Element) SetInverse(x *Element) *Element {
+	invertVartime(z.num(), x.Num, x)
+	z.P = x.P
+	z.m = x.m
+
+	return z
+}
+
+// num returns z.Num, allocating it first if z is the zero value.
+func (z *Element) num() *big.Int {
+	if z.Num == nil {
+		z.Num = new(big.Int)
+	}
+
+	return z.Num
+}
+
+// reduce sets z to the non-negative value n reduced into the field of x.
+func (z *Element) reduce(n *big.Int, x *Element) {
+	// Mod allocates a new quotient on every call, so QuoRem is used with a
+	// pooled one instead.
+	q := intPool.Get().(*big.Int)
+	q.QuoRem(n, x.P, z.num())
+	intPool.Put(q)
+
+	z.P = x.P
+	z.m = x.m
+}
+
+// mustSameField panics if the two Elements are not in the same finite field.
+func (e *Element) mustSameField(o *Element) {
+	if !e.sameField(o) {
+		panic(ErrElementsOfDifferentFields)
+	}
+}
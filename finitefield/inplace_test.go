@@ -0,0 +1,181 @@
+package finitefield
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestInPlaceArithmetic checks the in-place methods against the methods that
+// return a new Element, including when the receiver aliases an operand.
+func TestInPlaceArithmetic(t *testing.T) {
+	for _, p := range testPrimes(t) {
+		p := p
+		t.Run(fmt.Sprintf("p=%x", p), func(t *testing.T) {
+			vals := testValues(t, p)
+			for _, x := range vals {
+				for _, y := range vals {
+					testInPlacePair(t, x, y, p)
+				}
+			}
+		})
+	}
+}
+
+func testInPlacePair(t *testing.T, x, y, p *big.Int) {
+	a, err := NewElement(x, p)
+	require.NoError(t, err)
+
+	b, err := NewElement(y, p)
+	require.NoError(t, err)
+
+	ops := []struct {
+		name string
+		exp  func() *Element
+		set  func(z, x, y *Element) *Element
+	}{
+		{
+			name: "add",
+			exp: func() *Element {
+				res, err := a.Add(b)
+				require.NoError(t, err)
+				return res
+			},
+			set: (*Element).SetAdd,
+		},
+		{
+			name: "sub",
+			exp: func() *Element {
+				res, err := a.Sub(b)
+				require.NoError(t, err)
+				return res
+			},
+			set: (*Element).SetSub,
+		},
+		{
+			name: "mul",
+			exp: func() *Element {
+				res, err := a.Mul(b)
+				require.NoError(t, err)
+				return res
+			},
+			set: (*Element).SetMul,
+		},
+		{
+			name: "div",
+			exp: func() *Element {
+				res, err := a.Div(b)
+				require.NoError(t, err)
+				return res
+			},
+			set: (*Element).SetDiv,
+		},
+	}
+
+	for _, op := range ops {
+		exp := op.exp()
+
+		// Fresh receiver.
+		z := op.set(new(Element), a, b)
+		require.True(t, exp.Equal(z), op.name)
+
+		// Receiver aliasing the first and then the second operand.
+		z = new(Element).Set(a)
+		op.set(z, z, b)
+		require.True(t, exp.Equal(z), op.name)
+
+		z = new(Element).Set(b)
+		op.set(z, a, z)
+		require.True(t, exp.Equal(z), op.name)
+	}
+
+	z := new(Element).Set(a)
+	require.True(t, a.Negate().Equal(z.SetNegate(z)))
+
+	z = new(Element).Set(a)
+	require.True(t, a.Pow(y).Equal(z.SetPow(z, y)))
+
+	z = new(Element).Set(a)
+	sq, err := a.Mul(a)
+	require.NoError(t, err)
+	require.True(t, sq.Equal(z.SetSquare(z)))
+
+	// The operands are never modified.
+	require.Zero(t, a.Num.Cmp(x))
+	require.Zero(t, b.Num.Cmp(y))
+}
+
+// TestInPlaceDifferentFields checks that the in-place methods panic if the
+// operands are in different fields.
+func TestInPlaceDifferentFields(t *testing.T) {
+	a, err := NewElement(big.NewInt(3), big.NewInt(19))
+	require.NoError(t, err)
+
+	b, err := NewElement(big.NewInt(3), big.NewInt(223))
+	require.NoError(t, err)
+
+	require.PanicsWithValue(t, ErrElementsOfDifferentFields, func() {
+		new(Element).SetAdd(a, b)
+	})
+	require.PanicsWithValue(t, ErrElementsOfDifferentFields, func() {
+		new(Element).SetMul(a, b)
+	})
+}
+
+// benchElements returns two random Elements of the secp256k1 base field.
+func benchElements(b *testing.B) (*Element, *Element) {
+	p := testPrimes(b)[5]
+	vals := testValues(b, p)
+
+	x, err := NewElement(vals[len(vals)-1], p)
+	require.NoError(b, err)
+
+	y, err := NewElement(vals[len(vals)-2], p)
+	require.NoError(b, err)
+
+	return x, y
+}
+
+func BenchmarkElementAdd(b *testing.B) {
+	x, y := benchElements(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = x.Add(y)
+	}
+}
+
+func BenchmarkElementSetAdd(b *testing.B) {
+	x, y := benchElements(b)
+	z := new(Element).Set(x)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		z.SetAdd(z, y)
+	}
+}
+
+func BenchmarkElementMul(b *testing.B) {
+	x, y := benchElements(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = x.Mul(y)
+	}
+}
+
+func BenchmarkElementSetMul(b *testing.B) {
+	x, y := benchElements(b)
+	z := new(Element).Set(x)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		z.SetMul(z, y)
+	}
+}
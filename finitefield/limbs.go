@@ -0,0 +1,247 @@
+package finitefield
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// The helpers in this file operate on fixed-width little-endian slices of
+// 64-bit limbs. None of them branch on or index memory by the values of the
+// limbs so their running time only depends on the number of limbs.
+
+// modulus holds the limb representation of the order of a finite field for use
+// by the constant time arithmetic.
+type modulus struct {
+	// p is the order of the field.
+	p *big.Int
+
+	// elemP is a copy of p that is shared as the P of the Elements of the
+	// field. It is kept apart from p so that an Element whose P is
+	// modified, which must never happen, does not change the field itself.
+	elemP *big.Int
+
+	// limbs is p split into 64-bit limbs.
+	limbs []uint64
+
+	// byteLen is the number of bytes needed to encode an element of the
+	// field.
+	byteLen int
+
+	// mont is the Montgomery context used for multiplication. It is nil if
+	// p is even.
+	mont *Montgomery
+
+	// inv is used to compute inverses. It is nil if p is even.
+	inv *Inverter
+}
+
+// newModulus constructs a new modulus for the given field order.
+func newModulus(p *big.Int) *modulus {
+	byteLen := (p.BitLen() + 7) / 8
+
+	m := &modulus{
+		p:       p,
+		elemP:   new(big.Int).Set(p),
+		limbs:   limbsFromBytes(p.FillBytes(make([]byte, byteLen))),
+		byteLen: byteLen,
+	}
+
+	if p.Bit(0) == 1 && p.Cmp(one) > 0 {
+		m.mont = newMontgomery(m)
+		m.inv = newInverter(m.limbs, p.BitLen())
+	}
+
+	return m
+}
+
+// mul sets z = x * y mod p. Both x and y must be less than p. z must not alias
+// x or y.
+func (m *modulus) mul(z, x, y []uint64) {
+	if m.mont == nil {
+		modMul(z, x, y, m.limbs)
+		return
+	}
+
+	// The first REDC leaves an extra factor of R^-1 which the second one
+	// cancels out by multiplying with R^2.
+	montMul(z, x, y, m.limbs, m.mont.pInv)
+	montMul(z, z, m.mont.rr, m.limbs, m.mont.pInv)
+}
+
+// limbsFromBytes converts the big-endian byte slice into little-endian 64-bit
+// limbs.
+func limbsFromBytes(b []byte) []uint64 {
+	limbs := make([]uint64, (len(b)+7)/8)
+	for i := range b {
+		shift := 8 * uint((len(b)-1-i)%8)
+		limbs[(len(b)-1-i)/8] |= uint64(b[i]) << shift
+	}
+
+	return limbs
+}
+
+// limbsToBytes writes the little-endian limbs to b in big-endian order. The
+// value must fit in len(b) bytes.
+func limbsToBytes(b []byte, limbs []uint64) {
+	for i := range b {
+		shift := 8 * uint((len(b)-1-i)%8)
+		b[i] = byte(limbs[(len(b)-1-i)/8] >> shift)
+	}
+}
+
+// limbsAdd sets z = x + y and returns the carry.
+func limbsAdd(z, x, y []uint64) uint64 {
+	var carry uint64
+	for i := range z {
+		z[i], carry = bits.Add64(x[i], y[i], carry)
+	}
+
+	return carry
+}
+
+// limbsSub sets z = x - y and returns the borrow.
+func limbsSub(z, x, y []uint64) uint64 {
+	var borrow uint64
+	for i := range z {
+		z[i], borrow = bits.Sub64(x[i], y[i], borrow)
+	}
+
+	return borrow
+}
+
+// limbsSelect sets z = x if mask is all ones and z = y if mask is zero.
+func limbsSelect(z []uint64, mask uint64, x, y []uint64) {
+	for i := range z {
+		z[i] = (x[i] & mask) | (y[i] &^ mask)
+	}
+}
+
+// limbsIsZero returns 1 if x is zero and 0 otherwise.
+func limbsIsZero(x []uint64) int {
+	var acc uint64
+	for i := range x {
+		acc |= x[i]
+	}
+
+	// acc | -acc has its top bit set if and only if acc is non-zero.
+	return int(1 ^ ((acc | -acc) >> 63))
+}
+
+// modAdd sets z = x + y mod p. Both x and y must be less than p and tmp must
+// be a scratch slice of the same length.
+func modAdd(z, x, y, p, tmp []uint64) {
+	carry := limbsAdd(z, x, y)
+	borrow := limbsSub(tmp, z, p)
+
+	// The reduced value is z - p if the addition overflowed or if z is at
+	// least p.
+	mask := -(carry | (borrow ^ 1))
+	limbsSelect(z, mask, tmp, z)
+}
+
+// modSub sets z = x - y mod p. Both x and y must be less than p and tmp must
+// be a scratch slice of the same length.
+func modSub(z, x, y, p, tmp []uint64) {
+	borrow := limbsSub(z, x, y)
+	limbsAdd(tmp, z, p)
+	limbsSelect(z, -borrow, tmp, z)
+}
+
+// modMul sets z = x * y mod p using interleaved shift-and-add multiplication.
+// Both x and y must be less than p. z must not alias x or y.
+func modMul(z, x, y, p []uint64) {
+	var (
+		tmp    = make([]uint64, len(p))
+		addend = make([]uint64, len(p))
+	)
+
+	for i := range z {
+		z[i] = 0
+	}
+
+	// Walk over every bit of y, from the most significant, regardless of
+	// the bit length of y so that the running time does not depend on it.
+	for i := len(y)*64 - 1; i >= 0; i-- {
+		// z = 2z
+		modAdd(z, z, z, p, tmp)
+
+		// z = z + bit*x
+		mask := -((y[i/64] >> uint(i%64)) & 1)
+		for j := range addend {
+			addend[j] = x[j] & mask
+		}
+		modAdd(z, z, addend, p, tmp)
+	}
+}
+
+// modReduceBytes sets z to the big-endian value b reduced modulo p. The running
+// time only depends on the length of b.
+func modReduceBytes(z []uint64, b []byte, p []uint64) {
+	var (
+		tmp    = make([]uint64, len(p))
+		addend = make([]uint64, len(p))
+	)
+
+	for i := range z {
+		z[i] = 0
+	}
+
+	for i := 0; i < len(b)*8; i++ {
+		bit := uint64(b[i/8]>>uint(7-i%8)) & 1
+
+		// z = 2z + bit
+		modAdd(z, z, z, p, tmp)
+		addend[0] = bit
+		modAdd(z, z, addend, p, tmp)
+	}
+}
+
+// madd returns the 128-bit value x*y + z + c split into its high and low 64
+// bits. The result can never overflow 128 bits.
+func madd(x, y, z, c uint64) (uint64, uint64) {
+	hi, lo := bits.Mul64(x, y)
+
+	var cc uint64
+	lo, cc = bits.Add64(lo, z, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+
+	return hi, lo
+}
+
+// montMul sets z = x * y * R^-1 mod p, where R = 2^(64*len(p)), using the
+// coarsely integrated operand scanning (CIOS) form of Montgomery reduction.
+// Both x and y must be less than p, p must be odd and pInv must be -p^-1 mod
+// 2^64. z may alias x or y.
+func montMul(z, x, y, p []uint64, pInv uint64) {
+	n := len(p)
+	t := make([]uint64, n+2)
+
+	for i := 0; i < n; i++ {
+		// t = t + x*y[i]
+		var c uint64
+		for j := 0; j < n; j++ {
+			c, t[j] = madd(x[j], y[i], t[j], c)
+		}
+		t[n], c = bits.Add64(t[n], c, 0)
+		t[n+1] = c
+
+		// Add the multiple of p that clears the lowest limb of t and
+		// then shift t down by one limb.
+		m := t[0] * pInv
+		c, _ = madd(m, p[0], t[0], 0)
+		for j := 1; j < n; j++ {
+			c, t[j-1] = madd(m, p[j], t[j], c)
+		}
+		t[n-1], c = bits.Add64(t[n], c, 0)
+		t[n] = t[n+1] + c
+	}
+
+	// The result is less than 2p so at most one subtraction of p is
+	// needed. It is needed if the result overflowed n limbs or if the
+	// subtraction does not borrow.
+	borrow := limbsSub(z, t[:n], p)
+	mask := -(t[n] | (borrow ^ 1))
+	limbsSelect(z, mask, z, t[:n])
+}
@@ -0,0 +1,246 @@
+package finitefield
+
+import (
+	"errors"
+	"math/big"
+)
+
+// ErrEvenModulus is returned when a Montgomery context or an Inverter is
+// requested for an even modulus.
+var ErrEvenModulus = errors.New("modulus must be odd and greater than 1")
+
+// Montgomery holds the values that are precomputed for doing arithmetic modulo
+// an odd number p in the Montgomery domain. A number x is represented in the
+// Montgomery domain by x*R mod p where R = 2^(64*k) and k is the number of
+// 64-bit limbs needed to hold p. Multiplication in the Montgomery domain
+// replaces the division by p with the much cheaper REDC reduction.
+//
+// All the arithmetic in the Montgomery domain runs in constant time.
+type Montgomery struct {
+	m *modulus
+
+	// pInv is -p^-1 mod 2^64.
+	pInv uint64
+
+	// r is R mod p which is 1 in the Montgomery domain.
+	r []uint64
+
+	// rr is R^2 mod p which is used to convert into the Montgomery
+	// domain.
+	rr []uint64
+}
+
+// NewMontgomery constructs a new Montgomery context for the given modulus which
+// must be odd and greater than 1. The modulus does not have to be prime but
+// Inverse only works for prime moduli.
+func NewMontgomery(p *big.Int) (*Montgomery, error) {
+	if p.Cmp(one) <= 0 || p.Bit(0) != 1 {
+		return nil, ErrEvenModulus
+	}
+
+	return newMontgomery(newModulus(p)), nil
+}
+
+// newMontgomery constructs a new Montgomery context for the given odd modulus.
+func newMontgomery(m *modulus) *Montgomery {
+	k := len(m.limbs)
+
+	// Newton's method doubles the number of correct low bits of the
+	// inverse with every iteration. Since p*p = 1 mod 8, p is already a
+	// 3-bit inverse of itself and so five iterations give 64 bits.
+	inv := m.limbs[0]
+	for i := 0; i < 5; i++ {
+		inv *= 2 - m.limbs[0]*inv
+	}
+
+	var r, rr big.Int
+	r.Lsh(one, uint(64*k))
+	rr.Mul(&r, &r)
+	r.Mod(&r, m.p)
+	rr.Mod(&rr, m.p)
+
+	return &Montgomery{
+		m:    m,
+		pInv: -inv,
+		r:    limbsFromBytes(r.FillBytes(make([]byte, 8*k))),
+		rr:   limbsFromBytes(rr.FillBytes(make([]byte, 8*k))),
+	}
+}
+
+// Modulus returns a copy of the modulus of the Montgomery context.
+func (m *Montgomery) Modulus() *big.Int {
+	return new(big.Int).Set(m.m.p)
+}
+
+// MontElement is a number held in the Montgomery domain of a Montgomery
+// context.
+type MontElement struct {
+	n    []uint64
+	mont *Montgomery
+}
+
+// newElement constructs a new zero MontElement.
+func (m *Montgomery) newElement() *MontElement {
+	return &MontElement{
+		n:    make([]uint64, len(m.m.limbs)),
+		mont: m,
+	}
+}
+
+// ToMont converts the given Element, which must have the same order as the
+// modulus of the Montgomery context, into the Montgomery domain.
+func (m *Montgomery) ToMont(e *Element) (*MontElement, error) {
+	if e.P.Cmp(m.m.p) != 0 {
+		return nil, ErrElementsOfDifferentFields
+	}
+
+	n := limbsFromBytes(e.Num.FillBytes(make([]byte, 8*len(m.m.limbs))))
+
+	return m.toMont(n), nil
+}
+
+// toMont converts the reduced limbs x into the Montgomery domain.
+func (m *Montgomery) toMont(x []uint64) *MontElement {
+	res := m.newElement()
+	montMul(res.n, x, m.rr, m.m.limbs, m.pInv)
+
+	return res
+}
+
+// One returns the MontElement that represents 1.
+func (m *Montgomery) One() *MontElement {
+	res := m.newElement()
+	copy(res.n, m.r)
+
+	return res
+}
+
+// fromMont writes the value of e taken out of the Montgomery domain to z.
+func (e *MontElement) fromMont(z []uint64) {
+	// Multiplying by 1 divides by R.
+	unit := make([]uint64, len(e.n))
+	unit[0] = 1
+
+	montMul(z, e.n, unit, e.mont.m.limbs, e.mont.pInv)
+}
+
+// FromMont converts the MontElement out of the Montgomery domain and returns it
+// as an Element.
+func (e *MontElement) FromMont() *Element {
+	z := make([]uint64, len(e.n))
+	e.fromMont(z)
+
+	b := make([]byte, 8*len(z))
+	limbsToBytes(b, z)
+
+	return &Element{
+		Num: new(big.Int).SetBytes(b),
+		P:   e.mont.m.elemP,
+		m:   e.mont.m,
+	}
+}
+
+// Add adds two MontElements of the same Montgomery context together.
+func (e *MontElement) Add(o *MontElement) (*MontElement, error) {
+	if err := e.checkContext(o); err != nil {
+		return nil, err
+	}
+
+	res := e.mont.newElement()
+	modAdd(res.n, e.n, o.n, e.mont.m.limbs, make([]uint64, len(e.n)))
+
+	return res, nil
+}
+
+// Sub subtracts the given MontElement from this MontElement.
+func (e *MontElement) Sub(o *MontElement) (*MontElement, error) {
+	if err := e.checkContext(o); err != nil {
+		return nil, err
+	}
+
+	res := e.mont.newElement()
+	modSub(res.n, e.n, o.n, e.mont.m.limbs, make([]uint64, len(e.n)))
+
+	return res, nil
+}
+
+// Mul multiplies the two MontElements together using REDC.
+func (e *MontElement) Mul(o *MontElement) (*MontElement, error) {
+	if err := e.checkContext(o); err != nil {
+		return nil, err
+	}
+
+	res := e.mont.newElement()
+	montMul(res.n, e.n, o.n, e.mont.m.limbs, e.mont.pInv)
+
+	return res, nil
+}
+
+// Exp raises the MontElement to the given non-negative exponent. Like
+// SecretElement.Pow, the running time only depends on the bit length of the
+// modulus as long as the exponent is not longer than it.
+func (e *MontElement) Exp(exp *big.Int) *MontElement {
+	res := e.mont.One()
+	e.mont.exp(res.n, e.n, exp)
+
+	return res
+}
+
+// exp sets z = x^exp in the Montgomery domain. z must hold the Montgomery
+// representation of 1 and must not alias x.
+func (m *Montgomery) exp(z, x []uint64, exp *big.Int) {
+	if exp.Sign() < 0 {
+		panic("negative exponent")
+	}
+
+	bitLen := m.m.p.BitLen()
+	if exp.BitLen() > bitLen {
+		bitLen = exp.BitLen()
+	}
+
+	// Square-and-always-multiply: the multiplication is done for every bit
+	// and its result is only kept if the bit is set.
+	tmp := make([]uint64, len(z))
+	for i := bitLen - 1; i >= 0; i-- {
+		montMul(z, z, z, m.m.limbs, m.pInv)
+		montMul(tmp, z, x, m.m.limbs, m.pInv)
+
+		mask := -uint64(exp.Bit(i))
+		limbsSelect(z, mask, tmp, z)
+	}
+}
+
+// Inverse returns the multiplicative inverse of the MontElement using Fermat's
+// little theorem. The modulus must be prime. The inverse of zero is zero.
+func (e *MontElement) Inverse() *MontElement {
+	return e.Exp(new(big.Int).Sub(e.mont.m.p, two))
+}
+
+// Equal returns true if the two MontElements represent the same number.
+func (e *MontElement) Equal(o *MontElement) bool {
+	if e.checkContext(o) != nil {
+		return false
+	}
+
+	diff := make([]uint64, len(e.n))
+	for i := range diff {
+		diff[i] = e.n[i] ^ o.n[i]
+	}
+
+	return limbsIsZero(diff) == 1
+}
+
+// IsZero returns true if the MontElement represents zero.
+func (e *MontElement) IsZero() bool {
+	return limbsIsZero(e.n) == 1
+}
+
+// checkContext returns ErrElementsOfDifferentFields if the two MontElements do
+// not have the same modulus.
+func (e *MontElement) checkContext(o *MontElement) error {
+	if e.mont != o.mont && e.mont.m.p.Cmp(o.mont.m.p) != 0 {
+		return ErrElementsOfDifferentFields
+	}
+
+	return nil
+}
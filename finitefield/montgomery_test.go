@@ -0,0 +1,147 @@
+package finitefield
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestMontgomery checks the Montgomery domain arithmetic against the math/big
+// based Element arithmetic for a number of odd moduli, including composite
+// ones.
+func TestMontgomery(t *testing.T) {
+	moduli := []*big.Int{big.NewInt(15), big.NewInt(3 * 5 * 7 * 11 * 13)}
+	for _, p := range testPrimes(t) {
+		if p.Bit(0) == 1 {
+			moduli = append(moduli, p)
+		}
+	}
+
+	for _, p := range moduli {
+		p := p
+		t.Run(fmt.Sprintf("p=%x", p), func(t *testing.T) {
+			mont, err := NewMontgomery(p)
+			require.NoError(t, err)
+			require.Equal(t, p, mont.Modulus())
+
+			vals := testValues(t, p)
+			for _, x := range vals {
+				a, err := NewElement(x, p)
+				require.NoError(t, err)
+
+				ma, err := mont.ToMont(a)
+				require.NoError(t, err)
+				require.True(t, a.Equal(ma.FromMont()))
+
+				for _, y := range vals {
+					b, err := NewElement(y, p)
+					require.NoError(t, err)
+
+					mb, err := mont.ToMont(b)
+					require.NoError(t, err)
+
+					testMontPair(t, a, b, ma, mb)
+				}
+			}
+		})
+	}
+}
+
+func testMontPair(t *testing.T, a, b *Element, ma, mb *MontElement) {
+	sum, err := a.Add(b)
+	require.NoError(t, err)
+	mSum, err := ma.Add(mb)
+	require.NoError(t, err)
+	require.True(t, sum.Equal(mSum.FromMont()))
+
+	diff, err := a.Sub(b)
+	require.NoError(t, err)
+	mDiff, err := ma.Sub(mb)
+	require.NoError(t, err)
+	require.True(t, diff.Equal(mDiff.FromMont()))
+
+	prod, err := a.Mul(b)
+	require.NoError(t, err)
+	mProd, err := ma.Mul(mb)
+	require.NoError(t, err)
+	require.True(t, prod.Equal(mProd.FromMont()))
+
+	exp := new(big.Int).Exp(a.Num, b.Num, a.P)
+	require.Equal(t, 0, exp.Cmp(ma.Exp(b.Num).FromMont().Num))
+
+	require.Equal(t, a.Equal(b), ma.Equal(mb))
+	require.Equal(t, a.IsZero(), ma.IsZero())
+}
+
+// TestMontgomeryInverse tests the Inverse method of MontElement.
+func TestMontgomeryInverse(t *testing.T) {
+	p := big.NewInt(223)
+
+	mont, err := NewMontgomery(p)
+	require.NoError(t, err)
+
+	for i := int64(1); i < 223; i++ {
+		e, err := NewElement(big.NewInt(i), p)
+		require.NoError(t, err)
+
+		me, err := mont.ToMont(e)
+		require.NoError(t, err)
+
+		res, err := me.Mul(me.Inverse())
+		require.NoError(t, err)
+		require.True(t, res.Equal(mont.One()))
+	}
+}
+
+// TestMontgomeryErrors checks that invalid moduli and mixed contexts are
+// rejected.
+func TestMontgomeryErrors(t *testing.T) {
+	for _, p := range []int64{-3, 0, 1, 2, 224} {
+		_, err := NewMontgomery(big.NewInt(p))
+		require.ErrorIs(t, err, ErrEvenModulus)
+	}
+
+	m1, err := NewMontgomery(big.NewInt(223))
+	require.NoError(t, err)
+
+	m2, err := NewMontgomery(big.NewInt(19))
+	require.NoError(t, err)
+
+	e, err := NewElement(big.NewInt(3), big.NewInt(19))
+	require.NoError(t, err)
+
+	_, err = m1.ToMont(e)
+	require.ErrorIs(t, err, ErrElementsOfDifferentFields)
+
+	_, err = m1.One().Mul(m2.One())
+	require.ErrorIs(t, err, ErrElementsOfDifferentFields)
+}
+
+func BenchmarkMontgomeryMul(b *testing.B) {
+	p := testPrimes(b)[5]
+
+	mont, err := NewMontgomery(p)
+	require.NoError(b, err)
+
+	x, err := mont.ToMont(&Element{Num: new(big.Int).Rsh(p, 1), P: p})
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x, _ = x.Mul(x)
+	}
+}
+
+func BenchmarkSecretElementMul(b *testing.B) {
+	p := testPrimes(b)[5]
+	x := (&Element{Num: new(big.Int).Rsh(p, 1), P: p}).Secret()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x, _ = x.Mul(x)
+	}
+}
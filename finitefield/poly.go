@@ -0,0 +1,532 @@
+package finitefield
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+)
+
+var (
+	// ErrDivisionByZeroPoly is returned when a Poly is divided by the zero
+	// polynomial.
+	ErrDivisionByZeroPoly = errors.New("division by the zero polynomial")
+
+	// ErrDuplicateShare is returned when two shares passed to one of the
+	// interpolation functions have the same x coordinate.
+	ErrDuplicateShare = errors.New("shares must have distinct x " +
+		"coordinates")
+
+	// ErrNoShares is returned when no shares are passed to one of the
+	// interpolation functions.
+	ErrNoShares = errors.New("at least one share is required")
+
+	// ErrNegativeDegree is returned when a Poly of negative degree is
+	// requested.
+	ErrNegativeDegree = errors.New("degree must not be negative")
+)
+
+// Poly is a polynomial with coefficients in a finite field.
+type Poly struct {
+	// Coeffs holds the coefficients of the polynomial, starting with the
+	// constant term. In other words, Coeffs[i] is the coefficient of x^i.
+	// The last coefficient is never zero so the zero polynomial has no
+	// coefficients at all.
+	Coeffs []*Element
+
+	// P is the order of the finite field that the coefficients are in.
+	P *big.Int
+}
+
+// NewPoly constructs a new Poly over the field of order p with the given
+// coefficients, starting with the constant term.
+func NewPoly(p *big.Int, coeffs ...*Element) (*Poly, error) {
+	for _, c := range coeffs {
+		if c.P.Cmp(p) != 0 {
+			return nil, ErrElementsOfDifferentFields
+		}
+	}
+
+	poly := &Poly{
+		Coeffs: append([]*Element(nil), coeffs...),
+		P:      p,
+	}
+
+	return poly.trim(), nil
+}
+
+// RandomPoly returns a polynomial of the given degree with uniformly random
+// coefficients, apart from the constant term which is set to constant. This is
+// the polynomial that a dealer uses to split the secret constant into shares
+// in a threshold scheme such as Shamir's secret sharing.
+func RandomPoly(rand io.Reader, degree int, constant *Element) (*Poly,
+	error) {
+
+	if degree < 0 {
+		return nil, ErrNegativeDegree
+	}
+
+	coeffs := make([]*Element, degree+1)
+	coeffs[0] = constant
+
+	for i := 1; i <= degree; i++ {
+		c, err := randElement(rand, constant.P, constant.m)
+		if err != nil {
+			return nil, err
+		}
+
+		// Make sure that the polynomial really has the requested
+		// degree.
+		for i == degree && c.IsZero() {
+			c, err = randElement(rand, constant.P, constant.m)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		coeffs[i] = c
+	}
+
+	return NewPoly(constant.P, coeffs...)
+}
+
+// Degree returns the degree of the polynomial. The degree of the zero
+// polynomial is -1.
+func (p *Poly) Degree() int {
+	return len(p.Coeffs) - 1
+}
+
+// IsZero returns true if the Poly is the zero polynomial.
+func (p *Poly) IsZero() bool {
+	return len(p.Coeffs) == 0
+}
+
+// String returns a string representation of the Poly.
+func (p *Poly) String() string {
+	if p.IsZero() {
+		return fmt.Sprintf("Poly_%s(0)", p.P)
+	}
+
+	terms := make([]string, 0, len(p.Coeffs))
+	for i := len(p.Coeffs) - 1; i >= 0; i-- {
+		c := p.Coeffs[i]
+		if c.IsZero() {
+			continue
+		}
+
+		switch i {
+		case 0:
+			terms = append(terms, c.Num.String())
+		case 1:
+			terms = append(terms, fmt.Sprintf("%s*x", c.Num))
+		default:
+			terms = append(terms, fmt.Sprintf("%s*x^%d", c.Num, i))
+		}
+	}
+
+	return fmt.Sprintf("Poly_%s(%s)", p.P, strings.Join(terms, " + "))
+}
+
+// Equal returns true if the two polynomials are the same.
+func (p *Poly) Equal(o *Poly) bool {
+	if p.P.Cmp(o.P) != 0 || len(p.Coeffs) != len(o.Coeffs) {
+		return false
+	}
+
+	for i := range p.Coeffs {
+		if !p.Coeffs[i].Equal(o.Coeffs[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Add adds the two polynomials together.
+func (p *Poly) Add(o *Poly) (*Poly, error) {
+	if p.P.Cmp(o.P) != 0 {
+		return nil, ErrElementsOfDifferentFields
+	}
+
+	n := len(p.Coeffs)
+	if len(o.Coeffs) > n {
+		n = len(o.Coeffs)
+	}
+
+	coeffs := make([]*Element, n)
+	for i := range coeffs {
+		a, b := p.coeff(i), o.coeff(i)
+
+		c, err := a.Add(b)
+		if err != nil {
+			return nil, err
+		}
+
+		coeffs[i] = c
+	}
+
+	return NewPoly(p.P, coeffs...)
+}
+
+// Sub subtracts the given polynomial from this polynomial.
+func (p *Poly) Sub(o *Poly) (*Poly, error) {
+	neg := &Poly{
+		Coeffs: make([]*Element, len(o.Coeffs)),
+		P:      o.P,
+	}
+	for i, c := range o.Coeffs {
+		neg.Coeffs[i] = c.Negate()
+	}
+
+	return p.Add(neg)
+}
+
+// Mul multiplies the two polynomials together.
+func (p *Poly) Mul(o *Poly) (*Poly, error) {
+	if p.P.Cmp(o.P) != 0 {
+		return nil, ErrElementsOfDifferentFields
+	}
+
+	if p.IsZero() || o.IsZero() {
+		return NewPoly(p.P)
+	}
+
+	coeffs := make([]*Element, len(p.Coeffs)+len(o.Coeffs)-1)
+	for i := range coeffs {
+		coeffs[i] = p.zero()
+	}
+
+	for i, a := range p.Coeffs {
+		for j, b := range o.Coeffs {
+			ab, err := a.Mul(b)
+			if err != nil {
+				return nil, err
+			}
+
+			coeffs[i+j], err = coeffs[i+j].Add(ab)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return NewPoly(p.P, coeffs...)
+}
+
+// Eval evaluates the polynomial at x using Horner's method.
+func (p *Poly) Eval(x *Element) (*Element, error) {
+	if p.P.Cmp(x.P) != 0 {
+		return nil, ErrElementsOfDifferentFields
+	}
+
+	var (
+		res = p.zero()
+		err error
+	)
+	for i := len(p.Coeffs) - 1; i >= 0; i-- {
+		res, err = res.Mul(x)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err = res.Add(p.Coeffs[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// DivMod divides this polynomial by the given polynomial and returns the
+// quotient q and remainder r such that p = q*o + r and the degree of r is less
+// than the degree of o.
+func (p *Poly) DivMod(o *Poly) (*Poly, *Poly, error) {
+	if p.P.Cmp(o.P) != 0 {
+		return nil, nil, ErrElementsOfDifferentFields
+	}
+
+	if o.IsZero() {
+		return nil, nil, ErrDivisionByZeroPoly
+	}
+
+	// Long division: repeatedly cancel the leading term of the remainder
+	// with a multiple of o.
+	leadInv, err := o.Coeffs[o.Degree()].Inverse()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	quo := make([]*Element, 1)
+	if p.Degree() >= o.Degree() {
+		quo = make([]*Element, p.Degree()-o.Degree()+1)
+	}
+	for i := range quo {
+		quo[i] = p.zero()
+	}
+
+	rem := append([]*Element(nil), p.Coeffs...)
+	for len(rem) > 0 && len(rem)-1 >= o.Degree() {
+		shift := len(rem) - 1 - o.Degree()
+
+		c, err := rem[len(rem)-1].Mul(leadInv)
+		if err != nil {
+			return nil, nil, err
+		}
+		quo[shift] = c
+
+		for i, oc := range o.Coeffs {
+			t, err := c.Mul(oc)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			rem[shift+i], err = rem[shift+i].Sub(t)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		rem = (&Poly{Coeffs: rem, P: p.P}).trim().Coeffs
+	}
+
+	q, err := NewPoly(p.P, quo...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r, err := NewPoly(p.P, rem...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return q, r, nil
+}
+
+// coeff returns the coefficient of x^i, which is zero if i is greater than the
+// degree of the polynomial.
+func (p *Poly) coeff(i int) *Element {
+	if i < len(p.Coeffs) {
+		return p.Coeffs[i]
+	}
+
+	return p.zero()
+}
+
+// zero returns the zero Element of the field of the polynomial.
+func (p *Poly) zero() *Element {
+	return &Element{
+		Num: new(big.Int),
+		P:   p.P,
+	}
+}
+
+// trim removes any zero leading coefficients and returns the Poly.
+func (p *Poly) trim() *Poly {
+	n := len(p.Coeffs)
+	for n > 0 && p.Coeffs[n-1].IsZero() {
+		n--
+	}
+	p.Coeffs = p.Coeffs[:n]
+
+	return p
+}
+
+// Share is a point on a polynomial. In a threshold scheme, each participant
+// holds one Share of the polynomial that the secret was split with.
+type Share struct {
+	X *Element
+	Y *Element
+}
+
+// LagrangeCoefficients returns the Lagrange basis polynomials for the given
+// distinct x coordinates evaluated at the point at. The value at the point at
+// of the unique polynomial of degree less than len(xs) that passes through
+// (xs[i], ys[i]) is then the sum of coeffs[i]*ys[i].
+//
+// In threshold signing schemes such as FROST, the coefficients at zero are
+// what each signer multiplies its share of the secret key with.
+func LagrangeCoefficients(xs []*Element, at *Element) ([]*Element, error) {
+	if err := checkDistinct(xs, at.P); err != nil {
+		return nil, err
+	}
+
+	// The coefficient for i is:
+	//	prod_{j != i} (at - x_j) / (x_i - x_j)
+	nums := make([]*Element, len(xs))
+	dens := make([]*Element, len(xs))
+	for i, xi := range xs {
+		num, den := at.newElement(big.NewInt(1)), at.newElement(big.NewInt(1))
+
+		for j, xj := range xs {
+			if i == j {
+				continue
+			}
+
+			t, err := at.Sub(xj)
+			if err != nil {
+				return nil, err
+			}
+
+			num, err = num.Mul(t)
+			if err != nil {
+				return nil, err
+			}
+
+			t, err = xi.Sub(xj)
+			if err != nil {
+				return nil, err
+			}
+
+			den, err = den.Mul(t)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		nums[i], dens[i] = num, den
+	}
+
+	// All the denominators can be inverted at once since the x coordinates
+	// are distinct and so none of them are zero.
+	invs, err := BatchInvert(dens)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range nums {
+		nums[i], err = nums[i].Mul(invs[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return nums, nil
+}
+
+// Interpolate evaluates, at the point at, the unique polynomial of degree less
+// than len(shares) that passes through all the given shares. To recover a
+// secret that was shared as the constant term of a polynomial, at should be
+// zero.
+func Interpolate(shares []*Share, at *Element) (*Element, error) {
+	xs := make([]*Element, len(shares))
+	for i, s := range shares {
+		xs[i] = s.X
+	}
+
+	coeffs, err := LagrangeCoefficients(xs, at)
+	if err != nil {
+		return nil, err
+	}
+
+	res := at.newElement(new(big.Int))
+	for i, s := range shares {
+		t, err := coeffs[i].Mul(s.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err = res.Add(t)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// InterpolatePoly returns the unique polynomial of degree less than
+// len(shares) that passes through all the given shares.
+func InterpolatePoly(shares []*Share) (*Poly, error) {
+	if len(shares) == 0 {
+		return nil, ErrNoShares
+	}
+
+	p := shares[0].X.P
+	xs := make([]*Element, len(shares))
+	for i, s := range shares {
+		xs[i] = s.X
+	}
+
+	// Make sure that the x coordinates are distinct before dividing by
+	// their differences.
+	if err := checkDistinct(xs, p); err != nil {
+		return nil, err
+	}
+
+	res, err := NewPoly(p)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sum up y_i * L_i(x) where L_i is the Lagrange basis polynomial:
+	//	L_i(x) = prod_{j != i} (x - x_j) / (x_i - x_j)
+	for i, si := range shares {
+		basis, err := NewPoly(p, si.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		for j, sj := range shares {
+			if i == j {
+				continue
+			}
+
+			den, err := si.X.Sub(sj.X)
+			if err != nil {
+				return nil, err
+			}
+
+			denInv, err := den.Inverse()
+			if err != nil {
+				return nil, err
+			}
+
+			// (x - x_j) / (x_i - x_j)
+			c0, err := sj.X.Negate().Mul(denInv)
+			if err != nil {
+				return nil, err
+			}
+
+			term, err := NewPoly(p, c0, denInv)
+			if err != nil {
+				return nil, err
+			}
+
+			basis, err = basis.Mul(term)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		res, err = res.Add(basis)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// checkDistinct returns an error if the given x coordinates are not distinct
+// Elements of the field of order p.
+func checkDistinct(xs []*Element, p *big.Int) error {
+	if len(xs) == 0 {
+		return ErrNoShares
+	}
+
+	for i, x := range xs {
+		if x.P.Cmp(p) != 0 {
+			return ErrElementsOfDifferentFields
+		}
+
+		for _, y := range xs[:i] {
+			if x.Equal(y) {
+				return ErrDuplicateShare
+			}
+		}
+	}
+
+	return nil
+}
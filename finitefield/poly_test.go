@@ -0,0 +1,203 @@
+package finitefield
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestPoly constructs a Poly over the field of order p from the given
+// coefficients.
+func newTestPoly(t *testing.T, p int64, coeffs ...int64) *Poly {
+	prime := big.NewInt(p)
+
+	elems := make([]*Element, len(coeffs))
+	for i, c := range coeffs {
+		e, err := NewElement(big.NewInt(c), prime)
+		require.NoError(t, err)
+
+		elems[i] = e
+	}
+
+	poly, err := NewPoly(prime, elems...)
+	require.NoError(t, err)
+
+	return poly
+}
+
+// TestPolyArithmetic tests the Add, Sub, Mul and Eval methods of Poly.
+func TestPolyArithmetic(t *testing.T) {
+	// a = 3 + 2x + x^2
+	a := newTestPoly(t, 223, 3, 2, 1)
+
+	// b = 220 + x, or x - 3.
+	b := newTestPoly(t, 223, 220, 1)
+
+	// Leading zero coefficients are dropped.
+	require.Equal(t, 1, newTestPoly(t, 223, 220, 1, 0, 0).Degree())
+	require.Equal(t, -1, newTestPoly(t, 223, 0).Degree())
+
+	sum, err := a.Add(b)
+	require.NoError(t, err)
+	require.True(t, newTestPoly(t, 223, 0, 3, 1).Equal(sum))
+
+	diff, err := a.Sub(a)
+	require.NoError(t, err)
+	require.True(t, diff.IsZero())
+
+	// (3 + 2x + x^2)(x - 3) = -9 - 3x - x^2 + x^3
+	prod, err := a.Mul(b)
+	require.NoError(t, err)
+	require.True(t, newTestPoly(t, 223, 214, 220, 222, 1).Equal(prod))
+
+	x, err := NewElement(big.NewInt(5), big.NewInt(223))
+	require.NoError(t, err)
+
+	// a(5) = 3 + 10 + 25 = 38
+	y, err := a.Eval(x)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(38), y.Num)
+
+	// b(3) = 0
+	x, err = NewElement(big.NewInt(3), big.NewInt(223))
+	require.NoError(t, err)
+
+	y, err = b.Eval(x)
+	require.NoError(t, err)
+	require.True(t, y.IsZero())
+
+	// Polynomials over different fields can't be mixed.
+	_, err = a.Add(newTestPoly(t, 19, 1))
+	require.ErrorIs(t, err, ErrElementsOfDifferentFields)
+}
+
+// TestPolyDivMod checks that DivMod returns a quotient and remainder that
+// reconstruct the dividend.
+func TestPolyDivMod(t *testing.T) {
+	prime := big.NewInt(223)
+
+	for _, degrees := range [][2]int{{0, 0}, {3, 1}, {5, 2}, {2, 5}, {6, 6}} {
+		a, err := RandomPoly(rand.Reader, degrees[0], newTestPoly(
+			t, 223, 7,
+		).Coeffs[0])
+		require.NoError(t, err)
+
+		b, err := RandomPoly(rand.Reader, degrees[1], newTestPoly(
+			t, 223, 11,
+		).Coeffs[0])
+		require.NoError(t, err)
+
+		q, r, err := a.DivMod(b)
+		require.NoError(t, err)
+		require.Less(t, r.Degree(), b.Degree())
+
+		qb, err := q.Mul(b)
+		require.NoError(t, err)
+
+		res, err := qb.Add(r)
+		require.NoError(t, err)
+		require.True(t, a.Equal(res))
+	}
+
+	// (x^3 - 9 - 3x - x^2) / (x - 3) = 3 + 2x + x^2
+	q, r, err := newTestPoly(t, 223, 214, 220, 222, 1).DivMod(
+		newTestPoly(t, 223, 220, 1),
+	)
+	require.NoError(t, err)
+	require.True(t, r.IsZero())
+	require.True(t, newTestPoly(t, 223, 3, 2, 1).Equal(q))
+
+	_, _, err = q.DivMod(&Poly{P: prime})
+	require.ErrorIs(t, err, ErrDivisionByZeroPoly)
+}
+
+// TestSecretSharing splits a secret modulo the secp256k1 group order with a
+// random polynomial and checks that any threshold sized subset of the shares
+// recovers it while fewer shares do not.
+func TestSecretSharing(t *testing.T) {
+	n, ok := new(big.Int).SetString(
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141",
+		16,
+	)
+	require.True(t, ok)
+
+	const (
+		threshold = 3
+		numShares = 5
+	)
+
+	secret, err := randElement(rand.Reader, n, nil)
+	require.NoError(t, err)
+
+	poly, err := RandomPoly(rand.Reader, threshold-1, secret)
+	require.NoError(t, err)
+	require.Equal(t, threshold-1, poly.Degree())
+	require.True(t, secret.Equal(poly.Coeffs[0]))
+
+	_, err = RandomPoly(rand.Reader, -1, secret)
+	require.ErrorIs(t, err, ErrNegativeDegree)
+
+	shares := make([]*Share, numShares)
+	for i := range shares {
+		x, err := NewElement(big.NewInt(int64(i+1)), n)
+		require.NoError(t, err)
+
+		y, err := poly.Eval(x)
+		require.NoError(t, err)
+
+		shares[i] = &Share{X: x, Y: y}
+	}
+
+	zero, err := NewElement(big.NewInt(0), n)
+	require.NoError(t, err)
+
+	for _, subset := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}} {
+		var sub []*Share
+		for _, i := range subset {
+			sub = append(sub, shares[i])
+		}
+
+		res, err := Interpolate(sub, zero)
+		require.NoError(t, err)
+		require.True(t, secret.Equal(res))
+
+		p, err := InterpolatePoly(sub)
+		require.NoError(t, err)
+		require.True(t, poly.Equal(p))
+	}
+
+	// Two shares are not enough.
+	res, err := Interpolate(shares[:2], zero)
+	require.NoError(t, err)
+	require.False(t, secret.Equal(res))
+
+	// Interpolating at the x coordinate of one of the shares gives the
+	// share back.
+	res, err = Interpolate(shares[:3], shares[4].X)
+	require.NoError(t, err)
+	require.True(t, shares[4].Y.Equal(res))
+}
+
+// TestInterpolateErrors checks that invalid sets of shares are rejected.
+func TestInterpolateErrors(t *testing.T) {
+	prime := big.NewInt(223)
+
+	x, err := NewElement(big.NewInt(1), prime)
+	require.NoError(t, err)
+
+	_, err = Interpolate(nil, x)
+	require.ErrorIs(t, err, ErrNoShares)
+
+	_, err = InterpolatePoly(nil)
+	require.ErrorIs(t, err, ErrNoShares)
+
+	shares := []*Share{{X: x, Y: x}, {X: x, Y: x}}
+
+	_, err = Interpolate(shares, x)
+	require.ErrorIs(t, err, ErrDuplicateShare)
+
+	_, err = InterpolatePoly(shares)
+	require.ErrorIs(t, err, ErrDuplicateShare)
+}
@@ -0,0 +1,429 @@
+package finitefield
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// This file implements modular inversion with the safegcd algorithm of
+// Bernstein and Yang (https://eprint.iacr.org/2019/266.pdf), following the
+// structure of the implementation in libsecp256k1.
+//
+// The algorithm repeatedly applies "divsteps" to a pair (f, g) that starts as
+// (p, x). Each divstep halves g after possibly swapping and subtracting, until
+// g reaches zero and f is ±1. Only the low bits of f and g decide what the next
+// few divsteps do, so the divsteps are applied in batches of 30: the batch is
+// computed on the low 32 bits alone and summarised by a 2x2 transition matrix
+// which is then applied to the full f and g, and to the pair (d, e) that tracks
+// the inverse.
+//
+// Numbers are held in a signed base 2^30 representation: all the limbs except
+// the last are in the range [0, 2^30) and the last limb is signed. With 30-bit
+// limbs and matrix entries of at most 2^30, every product fits in an int64.
+
+const (
+	// batchSteps is the number of divsteps done per batch.
+	batchSteps = 30
+
+	// limbMask30 masks off the low 30 bits of a limb.
+	limbMask30 = 1<<30 - 1
+)
+
+// Inverter computes multiplicative inverses modulo a fixed odd modulus with the
+// safegcd algorithm. Invert runs in constant time so it can be used on secrets
+// while InvertVartime is faster but must only be used on public values.
+type Inverter struct {
+	// p is the modulus in signed base 2^30.
+	p []int64
+
+	// pInv is p^-1 mod 2^30.
+	pInv uint64
+
+	// words is the number of 64-bit limbs of the modulus.
+	words int
+
+	// batches is the number of batches of divsteps after which g is
+	// guaranteed to be zero.
+	batches int
+}
+
+// NewInverter constructs a new Inverter for the given modulus which must be odd
+// and greater than 1. The modulus does not have to be prime, but only numbers
+// that are coprime to it have an inverse.
+func NewInverter(p *big.Int) (*Inverter, error) {
+	if p.Cmp(one) <= 0 || p.Bit(0) != 1 {
+		return nil, ErrEvenModulus
+	}
+
+	return newInverter(newModulus(p).limbs, p.BitLen()), nil
+}
+
+// newInverter constructs a new Inverter for the odd modulus with the given
+// little-endian 64-bit limbs and bit length.
+func newInverter(p []uint64, bitLen int) *Inverter {
+	// Theorem 11.2 of the paper bounds the number of divsteps that are
+	// needed to bring g to zero for inputs of the given bit length.
+	steps := (49*bitLen + 57) / 17
+	if bitLen < 46 {
+		steps = (49*bitLen + 80) / 17
+	}
+
+	// Newton's method doubles the number of correct low bits of the
+	// inverse with every iteration. p is a 3-bit inverse of itself so four
+	// iterations give 48 bits.
+	inv := p[0]
+	for i := 0; i < 4; i++ {
+		inv *= 2 - p[0]*inv
+	}
+
+	return &Inverter{
+		p:       toSigned30(make([]int64, bitLen/30+1), p),
+		pInv:    inv & limbMask30,
+		words:   len(p),
+		batches: (steps + batchSteps - 1) / batchSteps,
+	}
+}
+
+// Invert sets z to the inverse of x modulo p. Both slices must hold as many
+// 64-bit little-endian limbs as p and x must be less than p. The inverse of
+// zero is zero. z may alias x. The running time only depends on the size of p.
+func (inv *Inverter) Invert(z, x []uint64) {
+	inv.invert(z, x, false)
+}
+
+// InvertVartime is like Invert but stops as soon as the inverse is found, so
+// its running time depends on x. It must only be used on public values.
+func (inv *Inverter) InvertVartime(z, x []uint64) {
+	inv.invert(z, x, true)
+}
+
+// invert sets z to the inverse of x modulo p.
+func (inv *Inverter) invert(z, x []uint64, vartime bool) {
+	n := len(inv.p)
+
+	// The working values live on the stack unless the modulus is too large
+	// for them to fit. 18 limbs are enough for a 521-bit modulus.
+	var buf [4 * 18]int64
+	scratch := buf[:]
+	if 4*n > len(scratch) {
+		scratch = make([]int64, 4*n)
+	}
+
+	var (
+		f = scratch[0*n : 1*n]
+		g = scratch[1*n : 2*n]
+		d = scratch[2*n : 3*n]
+		e = scratch[3*n : 4*n]
+	)
+	copy(f, inv.p)
+	toSigned30(g, x)
+	e[0] = 1
+
+	// The invariants d*x = f and e*x = g mod p hold throughout, so once g
+	// is zero and f is ±1, ±d is the inverse of x.
+	delta := int64(1)
+	for i := 0; i < inv.batches; i++ {
+		var t matrix
+		if vartime {
+			if isZero30(g) {
+				break
+			}
+
+			delta, t = divstepsVartime(delta, low32(f), low32(g))
+		} else {
+			delta, t = divsteps(delta, low32(f), low32(g))
+		}
+
+		inv.updateDE(d, e, &t)
+		updateFG(f, g, &t)
+	}
+
+	inv.normalize(d, f[n-1])
+	fromSigned30(z[:inv.words], d)
+}
+
+// invertVartime sets z to the inverse of x modulo the prime order of the field
+// of e, or to zero if x is zero, with the variable time safegcd algorithm. The
+// Inverter of the Field of e is used if there is one, otherwise one is built
+// for the call.
+func invertVartime(z, x *big.Int, e *Element) {
+	p := e.P
+
+	// The only even prime is 2 in which every element is its own inverse.
+	if p.Bit(0) == 0 {
+		z.Set(x)
+		return
+	}
+
+	b := make([]byte, (p.BitLen()+7)/8)
+
+	var inv *Inverter
+	if e.m != nil {
+		inv = e.m.inv
+	} else {
+		inv = newInverter(limbsFromBytes(p.FillBytes(b)), p.BitLen())
+	}
+
+	xLimbs := limbsFromBytes(x.FillBytes(b))
+	inv.InvertVartime(xLimbs, xLimbs)
+
+	limbsToBytes(b, xLimbs)
+	z.SetBytes(b)
+}
+
+// matrix is the transition matrix of a batch of divsteps scaled by
+// 2^batchSteps:
+//
+//	2^30 * [f', g'] = [[u, v], [q, r]] * [f, g]
+type matrix struct {
+	u, v, q, r int64
+}
+
+// divsteps applies a batch of divsteps to the low bits of f and g in constant
+// time and returns the new delta along with the transition matrix.
+func divsteps(delta int64, f, g int64) (int64, matrix) {
+	u, v, q, r := int64(1), int64(0), int64(0), int64(1)
+
+	for i := 0; i < batchSteps; i++ {
+		// c1 is all ones if delta > 0 and c2 is all ones if g is odd.
+		c1 := (-delta) >> 63
+		c2 := -(g & 1)
+
+		// If both are set then replace (f, g) with (g, -f) and negate
+		// delta. The rest of the step is then the same as for an odd g
+		// without the swap.
+		swap := c1 & c2
+
+		x := (f ^ g) & swap
+		f ^= x
+		g ^= x
+		g = (g ^ swap) - swap
+
+		x = (u ^ q) & swap
+		u ^= x
+		q ^= x
+		q = (q ^ swap) - swap
+
+		x = (v ^ r) & swap
+		v ^= x
+		r ^= x
+		r = (r ^ swap) - swap
+
+		delta = (delta ^ swap) - swap
+
+		// If g is odd then add f to make it even.
+		g += f & c2
+		q += u & c2
+		r += v & c2
+
+		// Halve g. The division is exact so, to keep the matrix
+		// integral, the f row is doubled instead of halving the g row.
+		g >>= 1
+		u <<= 1
+		v <<= 1
+		delta++
+	}
+
+	return delta, matrix{u: u, v: v, q: q, r: r}
+}
+
+// divstepsVartime computes the same result as divsteps but skips over runs of
+// divsteps in which g is even.
+func divstepsVartime(delta int64, f, g int64) (int64, matrix) {
+	u, v, q, r := int64(1), int64(0), int64(0), int64(1)
+
+	for i := batchSteps; ; {
+		// Each trailing zero of g is a divstep that just halves g.
+		// Setting bit i makes sure that no more than i are done.
+		zeros := bits.TrailingZeros64(uint64(g) | 1<<uint(i))
+		g >>= uint(zeros)
+		u <<= uint(zeros)
+		v <<= uint(zeros)
+		delta += int64(zeros)
+		i -= zeros
+
+		if i == 0 {
+			break
+		}
+
+		// g is odd.
+		if delta > 0 {
+			f, g = g, -f
+			u, q = q, -u
+			v, r = r, -v
+			delta = -delta
+		}
+
+		g += f
+		q += u
+		r += v
+
+		g >>= 1
+		u <<= 1
+		v <<= 1
+		delta++
+		i--
+	}
+
+	return delta, matrix{u: u, v: v, q: q, r: r}
+}
+
+// updateFG sets [f, g] to t*[f, g] / 2^30. The division is exact.
+func updateFG(f, g []int64, t *matrix) {
+	n := len(f)
+
+	cf := t.u*f[0] + t.v*g[0]
+	cg := t.q*f[0] + t.r*g[0]
+	cf >>= 30
+	cg >>= 30
+
+	for i := 1; i < n; i++ {
+		cf += t.u*f[i] + t.v*g[i]
+		cg += t.q*f[i] + t.r*g[i]
+		f[i-1] = cf & limbMask30
+		g[i-1] = cg & limbMask30
+		cf >>= 30
+		cg >>= 30
+	}
+
+	f[n-1] = cf
+	g[n-1] = cg
+}
+
+// updateDE sets [d, e] to t*[d, e] / 2^30 mod p. Both d and e must be in the
+// range (-2p, p) and the results are in the same range.
+func (inv *Inverter) updateDE(d, e []int64, t *matrix) {
+	n := len(d)
+	p := inv.p
+
+	// Start by adding p to the contributions of d and e if they are
+	// negative. This keeps the result in range.
+	sd := d[n-1] >> 63
+	se := e[n-1] >> 63
+	md := (t.u & sd) + (t.v & se)
+	me := (t.q & sd) + (t.r & se)
+
+	cd := t.u*d[0] + t.v*e[0]
+	ce := t.q*d[0] + t.r*e[0]
+
+	// Then adjust the multiples of p so that the low 30 bits of the
+	// results are zero and the division by 2^30 is exact.
+	md -= int64((inv.pInv*uint64(cd) + uint64(md)) & limbMask30)
+	me -= int64((inv.pInv*uint64(ce) + uint64(me)) & limbMask30)
+
+	cd += p[0] * md
+	ce += p[0] * me
+	cd >>= 30
+	ce >>= 30
+
+	for i := 1; i < n; i++ {
+		cd += t.u*d[i] + t.v*e[i] + p[i]*md
+		ce += t.q*d[i] + t.r*e[i] + p[i]*me
+		d[i-1] = cd & limbMask30
+		e[i-1] = ce & limbMask30
+		cd >>= 30
+		ce >>= 30
+	}
+
+	d[n-1] = cd
+	e[n-1] = ce
+}
+
+// normalize brings r from the range (-2p, p) into [0, p), negating it first if
+// sign is negative.
+func (inv *Inverter) normalize(r []int64, sign int64) {
+	n := len(r)
+
+	// Add p if r is negative to bring it into (-p, p).
+	mask := r[n-1] >> 63
+	for i := range r {
+		r[i] += inv.p[i] & mask
+	}
+
+	// Negate r if the sign is negative.
+	mask = sign >> 63
+	for i := range r {
+		r[i] = (r[i] ^ mask) - mask
+	}
+	carry30(r)
+
+	// Add p again if r is negative to bring it into [0, p).
+	mask = r[n-1] >> 63
+	for i := range r {
+		r[i] += inv.p[i] & mask
+	}
+	carry30(r)
+}
+
+// carry30 propagates the carries of r so that all the limbs except the last are
+// in the range [0, 2^30).
+func carry30(r []int64) {
+	for i := 0; i < len(r)-1; i++ {
+		r[i+1] += r[i] >> 30
+		r[i] &= limbMask30
+	}
+}
+
+// toSigned30 converts the little-endian 64-bit limbs x into limbs of 30 bits
+// and stores them in r, which is returned.
+func toSigned30(r []int64, x []uint64) []int64 {
+	for i := range r {
+		bit := 30 * i
+		w, shift := bit/64, uint(bit%64)
+		if w >= len(x) {
+			r[i] = 0
+			continue
+		}
+
+		v := x[w] >> shift
+		if shift > 34 && w+1 < len(x) {
+			v |= x[w+1] << (64 - shift)
+		}
+
+		r[i] = int64(v & limbMask30)
+	}
+
+	return r
+}
+
+// fromSigned30 converts r, which must be non-negative and have all its limbs in
+// the range [0, 2^30), into the little-endian 64-bit limbs z.
+func fromSigned30(z []uint64, r []int64) {
+	for i := range z {
+		z[i] = 0
+	}
+
+	for i, limb := range r {
+		bit := 30 * i
+		w, shift := bit/64, uint(bit%64)
+		if w >= len(z) {
+			break
+		}
+
+		z[w] |= uint64(limb) << shift
+		if shift > 34 && w+1 < len(z) {
+			z[w+1] |= uint64(limb) >> (64 - shift)
+		}
+	}
+}
+
+// low32 returns the low 32 bits of the signed base 2^30 number r.
+func low32(r []int64) int64 {
+	v := r[0]
+	if len(r) > 1 {
+		v += r[1] << 30
+	}
+
+	return int64(uint32(v))
+}
+
+// isZero30 returns true if the signed base 2^30 number r is zero.
+func isZero30(r []int64) bool {
+	for _, limb := range r {
+		if limb != 0 {
+			return false
+		}
+	}
+
+	return true
+}
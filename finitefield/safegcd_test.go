@@ -0,0 +1,215 @@
+package finitefield
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// inverterTestPrimes returns the odd test primes along with random primes of
+// bit lengths around the limb boundaries of both the 64-bit and the 30-bit
+// representations.
+func inverterTestPrimes(t testing.TB) []*big.Int {
+	var primes []*big.Int
+	for _, p := range testPrimes(t) {
+		if p.Bit(0) == 1 {
+			primes = append(primes, p)
+		}
+	}
+
+	for _, bitLen := range []int{
+		3, 29, 30, 31, 59, 60, 61, 64, 65, 90, 91, 128, 255, 257, 384,
+		521,
+	} {
+		p, err := rand.Prime(rand.Reader, bitLen)
+		require.NoError(t, err)
+
+		primes = append(primes, p)
+	}
+
+	return primes
+}
+
+// TestInverter checks both inversion methods of Inverter against math/big.
+func TestInverter(t *testing.T) {
+	for _, p := range inverterTestPrimes(t) {
+		p := p
+		t.Run(fmt.Sprintf("p=%x", p), func(t *testing.T) {
+			inv, err := NewInverter(p)
+			require.NoError(t, err)
+
+			words := len(newModulus(p).limbs)
+
+			for _, x := range testValues(t, p) {
+				exp := new(big.Int).ModInverse(x, p)
+				if exp == nil {
+					exp = new(big.Int)
+				}
+
+				xLimbs := limbsFromBytes(
+					x.FillBytes(make([]byte, 8*words)),
+				)
+
+				z := make([]uint64, words)
+				inv.Invert(z, xLimbs)
+				require.Zero(t, exp.Cmp(limbsToInt(z)), "x=%x", x)
+
+				inv.InvertVartime(z, xLimbs)
+				require.Zero(t, exp.Cmp(limbsToInt(z)), "x=%x", x)
+
+				// The result may be written over the input.
+				inv.Invert(xLimbs, xLimbs)
+				require.Zero(t, exp.Cmp(limbsToInt(xLimbs)), "x=%x", x)
+			}
+		})
+	}
+}
+
+// TestInverterSmallFields exhaustively checks the inverses in small fields.
+func TestInverterSmallFields(t *testing.T) {
+	for _, p := range []int64{3, 5, 7, 13, 223, 257, 65521} {
+		f, err := NewField(big.NewInt(p))
+		require.NoError(t, err)
+
+		for i := int64(1); i < p; i++ {
+			x := f.FromInt(i)
+
+			inv := x.Secret().Inverse().Element()
+			prod, err := x.Mul(inv)
+			require.NoError(t, err)
+			require.True(t, prod.Equal(f.One()), "p=%d x=%d", p, i)
+
+			inv, err = x.Inverse()
+			require.NoError(t, err)
+			prod, err = x.Mul(inv)
+			require.NoError(t, err)
+			require.True(t, prod.Equal(f.One()), "p=%d x=%d", p, i)
+		}
+
+		require.Equal(t, 1, f.Zero().Secret().Inverse().IsZero())
+	}
+}
+
+// TestInvertVartimeModulus checks that the results of arithmetic on the
+// Elements of a Field keep the modulus of the Field, and that Elements that
+// were not created through a Field still get correct inverses.
+func TestInvertVartimeModulus(t *testing.T) {
+	p, err := rand.Prime(rand.Reader, 200)
+	require.NoError(t, err)
+
+	f, err := NewField(p)
+	require.NoError(t, err)
+
+	x, err := NewElement(big.NewInt(12345), p)
+	require.NoError(t, err)
+	require.Nil(t, x.m)
+
+	withoutField, err := x.Inverse()
+	require.NoError(t, err)
+
+	y := f.FromInt(12345)
+	withField, err := y.Inverse()
+	require.NoError(t, err)
+	require.Same(t, f.m, withField.m)
+	require.True(t, withField.Equal(withoutField))
+
+	prod, err := y.Mul(withField)
+	require.NoError(t, err)
+	require.Same(t, f.m, prod.m)
+	require.True(t, prod.Equal(f.One()))
+	require.Same(t, f.m, y.Secret().m)
+}
+
+// TestLargeModulus checks the arithmetic of a field whose order is too large
+// for the stack buffers used for common orders.
+func TestLargeModulus(t *testing.T) {
+	// 2^1279 - 1 is a Mersenne prime.
+	p := new(big.Int).Sub(new(big.Int).Lsh(one, 1279), one)
+
+	f, err := NewField(p)
+	require.NoError(t, err)
+
+	x, err := NewElement(big.NewInt(12345), p)
+	require.NoError(t, err)
+
+	for _, e := range []*Element{x, f.FromInt(12345)} {
+		inv, err := e.Inverse()
+		require.NoError(t, err)
+
+		prod, err := e.Mul(inv)
+		require.NoError(t, err)
+		require.True(t, prod.Equal(f.One()))
+
+		quo, err := f.One().Div(e)
+		require.NoError(t, err)
+		require.True(t, quo.Equal(inv))
+
+		invs, err := BatchInvert([]*Element{e, e})
+		require.NoError(t, err)
+		require.True(t, invs[1].Equal(inv))
+
+		s, err := NewSecretElement(e.Num, e.P)
+		require.NoError(t, err)
+		require.True(t, s.Inverse().Element().Equal(inv))
+	}
+}
+
+// TestNewInverterEvenModulus checks that an Inverter can't be constructed for
+// an even modulus.
+func TestNewInverterEvenModulus(t *testing.T) {
+	for _, p := range []int64{0, 1, 2, 224} {
+		_, err := NewInverter(big.NewInt(p))
+		require.ErrorIs(t, err, ErrEvenModulus)
+	}
+}
+
+// limbsToInt converts the little-endian limbs to a big.Int.
+func limbsToInt(limbs []uint64) *big.Int {
+	b := make([]byte, 8*len(limbs))
+	limbsToBytes(b, limbs)
+
+	return new(big.Int).SetBytes(b)
+}
+
+// benchInverse returns the secp256k1 group order and a random number to invert
+// modulo it.
+func benchInverse(b *testing.B) (*big.Int, *SecretElement) {
+	p := testPrimes(b)[6]
+
+	return p, randomSecret(b, p)
+}
+
+func BenchmarkInvert(b *testing.B) {
+	_, x := benchInverse(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Inverse()
+	}
+}
+
+func BenchmarkInvertVartime(b *testing.B) {
+	_, x := benchInverse(b)
+	e := x.Element()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = e.Inverse()
+	}
+}
+
+func BenchmarkInvertFermat(b *testing.B) {
+	p, x := benchInverse(b)
+	exp := new(big.Int).Sub(p, two)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Pow(exp)
+	}
+}
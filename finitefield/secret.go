@@ -0,0 +1,281 @@
+package finitefield
+
+import (
+	"fmt"
+	"math/big"
+	"sync/atomic"
+)
+
+// lastModulus holds the modulus that was last built by NewSecretElement or
+// NewSecretElementFromBytes. Callers usually stick to a single field so this is
+// enough to avoid the precomputation on every call without keeping the modulus
+// of every order that was ever used.
+var lastModulus atomic.Pointer[modulus]
+
+// SecretElement is an Element that is meant to hold secret values such as
+// private keys and nonces. Unlike Element, which is built on math/big, all the
+// arithmetic on a SecretElement runs in constant time: the running time and the
+// memory access pattern only depend on the size of the field and never on the
+// values of the operands.
+//
+// Methods that would normally return a bool instead return an int that is 1
+// for true and 0 for false, in the style of crypto/subtle, so that the result
+// can be fed into the constant time Select and CondNegate methods without
+// branching.
+type SecretElement struct {
+	n []uint64
+	m *modulus
+}
+
+// NewSecretElement constructs a new SecretElement from the given number which
+// must be in the range [0, p).
+//
+// NOTE: big.Int values are not handled in constant time so, where possible,
+// NewSecretElementFromBytes should be used instead.
+func NewSecretElement(n, p *big.Int) (*SecretElement, error) {
+	if _, err := NewElement(n, p); err != nil {
+		return nil, err
+	}
+
+	return cachedModulus(p).secretElement(n), nil
+}
+
+// cachedModulus returns the modulus for the order p, reusing lastModulus if it
+// has the same order.
+func cachedModulus(p *big.Int) *modulus {
+	if m := lastModulus.Load(); m != nil && m.p.Cmp(p) == 0 {
+		return m
+	}
+
+	m := newModulus(new(big.Int).Set(p))
+	lastModulus.Store(m)
+
+	return m
+}
+
+// secretElement constructs a new SecretElement from n which must be in the
+// range [0, p).
+func (m *modulus) secretElement(n *big.Int) *SecretElement {
+	return &SecretElement{
+		n: limbsFromBytes(n.FillBytes(make([]byte, len(m.limbs)*8))),
+		m: m,
+	}
+}
+
+// NewSecretElementFromBytes constructs a new SecretElement from the big-endian
+// byte slice. The value is reduced modulo p in time that only depends on the
+// length of b.
+func NewSecretElementFromBytes(b []byte, p *big.Int) *SecretElement {
+	return cachedModulus(p).fromBytes(b)
+}
+
+// fromBytes constructs a new SecretElement from the big-endian byte slice
+// reduced modulo m.
+func (m *modulus) fromBytes(b []byte) *SecretElement {
+	e := m.newSecretElement()
+	modReduceBytes(e.n, b, m.limbs)
+
+	return e
+}
+
+// newSecretElement constructs a new zero SecretElement in the field of m.
+func (m *modulus) newSecretElement() *SecretElement {
+	return &SecretElement{
+		n: make([]uint64, len(m.limbs)),
+		m: m,
+	}
+}
+
+// Secret converts the Element to a SecretElement. The SecretElement reuses the
+// precomputed values of the Field of the Element if it has one.
+func (e *Element) Secret() *SecretElement {
+	if e.m != nil && e.Num.Sign() >= 0 && e.Num.Cmp(e.P) < 0 {
+		return e.m.secretElement(e.Num)
+	}
+
+	s, err := NewSecretElement(e.Num, e.P)
+	if err != nil {
+		// An Element is always in the range [0, P) so this can only
+		// happen if the Element was modified by hand.
+		panic(err)
+	}
+
+	return s
+}
+
+// Element converts the SecretElement to an Element. Any arithmetic done on the
+// result is no longer constant time.
+func (e *SecretElement) Element() *Element {
+	return &Element{
+		Num: new(big.Int).SetBytes(e.Bytes()),
+		P:   e.m.elemP,
+		m:   e.m,
+	}
+}
+
+// Bytes returns the fixed-width big-endian encoding of the SecretElement. The
+// width is the number of bytes needed to encode the order of the field.
+func (e *SecretElement) Bytes() []byte {
+	b := make([]byte, e.m.byteLen)
+	limbsToBytes(b, e.n)
+
+	return b
+}
+
+// String returns a string representation of the SecretElement. The value
+// itself is not included so that secrets do not end up in logs.
+func (e *SecretElement) String() string {
+	return fmt.Sprintf("SecretElement_%s(...)", e.m.p)
+}
+
+// Add adds two SecretElements in the same finite field together.
+func (e *SecretElement) Add(o *SecretElement) (*SecretElement, error) {
+	if err := e.checkField(o); err != nil {
+		return nil, err
+	}
+
+	res := e.m.newSecretElement()
+	modAdd(res.n, e.n, o.n, e.m.limbs, make([]uint64, len(e.n)))
+
+	return res, nil
+}
+
+// Sub subtracts the given SecretElement from this SecretElement.
+func (e *SecretElement) Sub(o *SecretElement) (*SecretElement, error) {
+	if err := e.checkField(o); err != nil {
+		return nil, err
+	}
+
+	res := e.m.newSecretElement()
+	modSub(res.n, e.n, o.n, e.m.limbs, make([]uint64, len(e.n)))
+
+	return res, nil
+}
+
+// Mul multiplies the two SecretElements together.
+func (e *SecretElement) Mul(o *SecretElement) (*SecretElement, error) {
+	if err := e.checkField(o); err != nil {
+		return nil, err
+	}
+
+	res := e.m.newSecretElement()
+	e.m.mul(res.n, e.n, o.n)
+
+	return res, nil
+}
+
+// Pow raises the SecretElement to the given non-negative exponent. The running
+// time depends on the bit length of the exponent only if it is longer than the
+// order of the field, so exponents in the range [0, p) may be secret.
+func (e *SecretElement) Pow(exp *big.Int) *SecretElement {
+	if exp.Sign() < 0 {
+		panic("negative exponent")
+	}
+
+	bitLen := e.m.p.BitLen()
+	if exp.BitLen() > bitLen {
+		bitLen = exp.BitLen()
+	}
+
+	res := e.m.newSecretElement()
+
+	// If the field has a Montgomery context then do the exponentiation in
+	// the Montgomery domain.
+	if mont := e.m.mont; mont != nil {
+		x := mont.toMont(e.n)
+		z := mont.One()
+		mont.exp(z.n, x.n, exp)
+		z.fromMont(res.n)
+
+		return res
+	}
+
+	// Square-and-always-multiply: the multiplication is done for every bit
+	// and its result is only kept if the bit is set.
+	tmp := make([]uint64, len(e.n))
+	res.n[0] = 1
+	for i := bitLen - 1; i >= 0; i-- {
+		modMul(tmp, res.n, res.n, e.m.limbs)
+		modMul(res.n, tmp, e.n, e.m.limbs)
+
+		mask := -uint64(exp.Bit(i))
+		limbsSelect(res.n, mask, res.n, tmp)
+	}
+
+	return res
+}
+
+// Inverse returns the multiplicative inverse of the SecretElement. The inverse
+// of zero is zero.
+func (e *SecretElement) Inverse() *SecretElement {
+	if e.m.inv == nil {
+		return e.Pow(new(big.Int).Sub(e.m.p, two))
+	}
+
+	res := e.m.newSecretElement()
+	e.m.inv.Invert(res.n, e.n)
+
+	return res
+}
+
+// Negate returns the additive inverse of the SecretElement.
+func (e *SecretElement) Negate() *SecretElement {
+	res := e.m.newSecretElement()
+	modSub(res.n, res.n, e.n, e.m.limbs, make([]uint64, len(e.n)))
+
+	return res
+}
+
+// CondNegate returns the additive inverse of the SecretElement if choice is 1
+// and a copy of the SecretElement if choice is 0.
+func (e *SecretElement) CondNegate(choice int) *SecretElement {
+	res := e.Negate()
+	limbsSelect(res.n, -uint64(choice&1), res.n, e.n)
+
+	return res
+}
+
+// Select returns a copy of o if choice is 1 and a copy of this SecretElement if
+// choice is 0.
+func (e *SecretElement) Select(o *SecretElement, choice int) (*SecretElement,
+	error) {
+
+	if err := e.checkField(o); err != nil {
+		return nil, err
+	}
+
+	res := e.m.newSecretElement()
+	limbsSelect(res.n, -uint64(choice&1), o.n, e.n)
+
+	return res, nil
+}
+
+// Equal returns 1 if the two SecretElements hold the same value in the same
+// field and 0 otherwise.
+func (e *SecretElement) Equal(o *SecretElement) int {
+	if e.checkField(o) != nil {
+		return 0
+	}
+
+	diff := make([]uint64, len(e.n))
+	for i := range diff {
+		diff[i] = e.n[i] ^ o.n[i]
+	}
+
+	return limbsIsZero(diff)
+}
+
+// IsZero returns 1 if the SecretElement is zero and 0 otherwise.
+func (e *SecretElement) IsZero() int {
+	return limbsIsZero(e.n)
+}
+
+// checkField returns ErrElementsOfDifferentFields if the two SecretElements are
+// not in the same finite field.
+func (e *SecretElement) checkField(o *SecretElement) error {
+	if e.m != o.m && e.m.p.Cmp(o.m.p) != 0 {
+		return ErrElementsOfDifferentFields
+	}
+
+	return nil
+}
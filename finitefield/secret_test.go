@@ -0,0 +1,197 @@
+package finitefield
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testPrimes returns a set of primes of various sizes, including ones that use
+// the top bit of their most significant limb.
+func testPrimes(t testing.TB) []*big.Int {
+	primes := []*big.Int{
+		big.NewInt(2),
+		big.NewInt(19),
+		big.NewInt(223),
+		// 2^61 - 1
+		new(big.Int).Sub(new(big.Int).Lsh(one, 61), one),
+	}
+
+	for _, h := range []string{
+		// 2^64 - 59
+		"FFFFFFFFFFFFFFC5",
+		// The secp256k1 field prime.
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F",
+		// The secp256k1 group order.
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141",
+		// The P-256 field prime.
+		"FFFFFFFF00000001000000000000000000000000FFFFFFFFFFFFFFFFFFFFFFFF",
+	} {
+		p, ok := new(big.Int).SetString(h, 16)
+		require.True(t, ok)
+
+		primes = append(primes, p)
+	}
+
+	return primes
+}
+
+// testValues returns a set of values in the field of order p that includes the
+// edge cases along with a few random values.
+func testValues(t testing.TB, p *big.Int) []*big.Int {
+	vals := []*big.Int{
+		big.NewInt(0),
+		new(big.Int).Sub(p, one),
+	}
+
+	if p.Cmp(two) > 0 {
+		vals = append(vals, big.NewInt(1), new(big.Int).Sub(p, two))
+	}
+
+	for i := 0; i < 4; i++ {
+		n, err := rand.Int(rand.Reader, p)
+		require.NoError(t, err)
+
+		vals = append(vals, n)
+	}
+
+	return vals
+}
+
+// TestSecretElementArithmetic checks the constant time arithmetic against the
+// math/big based Element arithmetic.
+func TestSecretElementArithmetic(t *testing.T) {
+	for _, p := range testPrimes(t) {
+		p := p
+		t.Run(fmt.Sprintf("p=%x", p), func(t *testing.T) {
+			vals := testValues(t, p)
+			for _, x := range vals {
+				for _, y := range vals {
+					testSecretElementPair(t, x, y, p)
+				}
+			}
+		})
+	}
+}
+
+func testSecretElementPair(t *testing.T, x, y, p *big.Int) {
+	a, err := NewElement(x, p)
+	require.NoError(t, err)
+
+	b, err := NewElement(y, p)
+	require.NoError(t, err)
+
+	sa, sb := a.Secret(), b.Secret()
+
+	sum, err := a.Add(b)
+	require.NoError(t, err)
+	sSum, err := sa.Add(sb)
+	require.NoError(t, err)
+	require.True(t, sum.Equal(sSum.Element()))
+
+	diff, err := a.Sub(b)
+	require.NoError(t, err)
+	sDiff, err := sa.Sub(sb)
+	require.NoError(t, err)
+	require.True(t, diff.Equal(sDiff.Element()))
+
+	prod, err := a.Mul(b)
+	require.NoError(t, err)
+	sProd, err := sa.Mul(sb)
+	require.NoError(t, err)
+	require.True(t, prod.Equal(sProd.Element()))
+
+	// Element.Pow reduces the exponent modulo p-1 which gives a different
+	// result for 0^(p-1), so only compare the two for non-zero bases.
+	if !a.IsZero() {
+		require.True(t, a.Pow(y).Equal(sa.Pow(y).Element()))
+	}
+
+	require.True(t, a.Negate().Equal(sa.Negate().Element()))
+
+	expEqual := 0
+	if a.Equal(b) {
+		expEqual = 1
+	}
+	require.Equal(t, expEqual, sa.Equal(sb))
+
+	if !a.IsZero() {
+		inv, err := a.Inverse()
+		require.NoError(t, err)
+		require.True(t, inv.Equal(sa.Inverse().Element()))
+	}
+}
+
+// TestSecretElementSelect tests the Select and CondNegate methods of
+// SecretElement.
+func TestSecretElementSelect(t *testing.T) {
+	p := big.NewInt(223)
+
+	a, err := NewSecretElement(big.NewInt(5), p)
+	require.NoError(t, err)
+
+	b, err := NewSecretElement(big.NewInt(7), p)
+	require.NoError(t, err)
+
+	res, err := a.Select(b, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Equal(a))
+
+	res, err = a.Select(b, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Equal(b))
+
+	require.Equal(t, 1, a.CondNegate(0).Equal(a))
+	require.Equal(t, 1, a.CondNegate(1).Equal(a.Negate()))
+	require.Equal(t, big.NewInt(218), a.CondNegate(1).Element().Num)
+
+	// Elements in different fields can't be mixed.
+	c, err := NewSecretElement(big.NewInt(5), big.NewInt(19))
+	require.NoError(t, err)
+
+	_, err = a.Select(c, 1)
+	require.ErrorIs(t, err, ErrElementsOfDifferentFields)
+	require.Equal(t, 0, a.Equal(c))
+}
+
+// TestNewSecretElementFromBytes checks that byte strings of any length are
+// correctly reduced.
+func TestNewSecretElementFromBytes(t *testing.T) {
+	for _, p := range testPrimes(t) {
+		for _, l := range []int{0, 1, 8, 32, 48, 64} {
+			b := make([]byte, l)
+			_, err := rand.Read(b)
+			require.NoError(t, err)
+
+			e := NewSecretElementFromBytes(b, p)
+
+			var exp big.Int
+			exp.SetBytes(b)
+			exp.Mod(&exp, p)
+
+			require.Equal(t, 0, exp.Cmp(e.Element().Num))
+			require.Len(t, e.Bytes(), (p.BitLen()+7)/8)
+		}
+	}
+}
+
+// TestSecretElementModulusCache checks that the constructors of SecretElement
+// reuse the modulus of the last order they were called with, and that the
+// cached modulus does not alias the order passed in.
+func TestSecretElementModulusCache(t *testing.T) {
+	p := big.NewInt(223)
+
+	a, err := NewSecretElement(big.NewInt(5), p)
+	require.NoError(t, err)
+
+	b := NewSecretElementFromBytes([]byte{7}, p)
+	require.Same(t, a.m, b.m)
+
+	p.SetInt64(19)
+	c := NewSecretElementFromBytes([]byte{7}, p)
+	require.NotSame(t, a.m, c.m)
+	require.Equal(t, big.NewInt(223), a.m.p)
+}
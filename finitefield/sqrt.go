@@ -0,0 +1,125 @@
+package finitefield
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	// ErrNoSquareRoot is returned when the square root of an Element that
+	// is not a quadratic residue is requested.
+	ErrNoSquareRoot = errors.New("element has no square root")
+
+	three = big.NewInt(3)
+	four  = big.NewInt(4)
+)
+
+// Legendre returns the Legendre symbol of the Element. It is 1 if the Element
+// is a non-zero quadratic residue, -1 if it is a non-residue and 0 if the
+// Element is zero. The order of the field must be an odd prime.
+func (e *Element) Legendre() int {
+	if e.IsZero() {
+		return 0
+	}
+
+	// Euler's criterion: e^((p-1)/2) is 1 for residues and -1 otherwise.
+	var exp big.Int
+	exp.Rsh(e.P, 1)
+
+	if e.Pow(&exp).Num.Cmp(one) == 0 {
+		return 1
+	}
+
+	return -1
+}
+
+// IsSquare returns true if the Element is a quadratic residue, in other words
+// if it has a square root in its field. Zero is considered to be a square.
+func (e *Element) IsSquare() bool {
+	return e.Legendre() >= 0
+}
+
+// Sqrt returns a square root of the Element. The other square root is its
+// negation. ErrNoSquareRoot is returned if the Element is not a quadratic
+// residue. The order of the field must be an odd prime.
+//
+// If the order of the field is 3 mod 4 then the root is found with a single
+// exponentiation. Otherwise, the Tonelli-Shanks algorithm is used.
+func (e *Element) Sqrt() (*Element, error) {
+	switch e.Legendre() {
+	case 0:
+		return e.newElement(new(big.Int)), nil
+	case -1:
+		return nil, ErrNoSquareRoot
+	}
+
+	// If p = 3 mod 4 then r = e^((p+1)/4) is a root since
+	// r^2 = e^((p+1)/2) = e * e^((p-1)/2) = e.
+	if new(big.Int).Mod(e.P, four).Cmp(three) == 0 {
+		var exp big.Int
+		exp.Add(e.P, one)
+		exp.Rsh(&exp, 2)
+
+		return e.Pow(&exp), nil
+	}
+
+	return e.tonelliShanks()
+}
+
+// tonelliShanks computes a square root of the Element using the Tonelli-Shanks
+// algorithm. The Element must be a non-zero quadratic residue.
+func (e *Element) tonelliShanks() (*Element, error) {
+	// Write p-1 as q*2^s with q odd.
+	var q big.Int
+	q.Sub(e.P, one)
+	s := q.TrailingZeroBits()
+	q.Rsh(&q, s)
+
+	// Find a quadratic non-residue z. Half of the elements are
+	// non-residues so this does not take long.
+	z := e.newElement(big.NewInt(2))
+	for z.Legendre() != -1 {
+		z = e.newElement(new(big.Int).Add(z.Num, one))
+	}
+
+	var exp big.Int
+	exp.Add(&q, one)
+	exp.Rsh(&exp, 1)
+
+	var (
+		m = s
+		c = z.Pow(&q)
+		t = e.Pow(&q)
+		r = e.Pow(&exp)
+
+		err error
+	)
+	for t.Num.Cmp(one) != 0 {
+		// Find the least i, 0 < i < m, such that t^(2^i) = 1.
+		i := uint(1)
+		t2 := t.Pow(two)
+		for t2.Num.Cmp(one) != 0 {
+			t2 = t2.Pow(two)
+			i++
+		}
+
+		// b = c^(2^(m-i-1))
+		b := c.Pow(new(big.Int).Lsh(one, m-i-1))
+		b2 := b.Pow(two)
+
+		m = i
+		c = b2
+
+		t, err = t.Mul(b2)
+		if err != nil {
+			return nil, err
+		}
+
+		r, err = r.Mul(b)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
@@ -0,0 +1,93 @@
+package finitefield
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestSqrt checks Legendre, IsSquare and Sqrt against an exhaustive search of
+// the squares in a number of small fields. Both fields with p = 3 mod 4 and
+// fields that need the full Tonelli-Shanks algorithm are covered.
+func TestSqrt(t *testing.T) {
+	primes := []int64{3, 5, 13, 17, 31, 41, 97, 223, 257}
+
+	for _, p := range primes {
+		p := p
+		t.Run(fmt.Sprintf("p=%d", p), func(t *testing.T) {
+			prime := big.NewInt(p)
+
+			// Collect all the squares in the field.
+			squares := make(map[int64]bool)
+			for i := int64(0); i < p; i++ {
+				squares[(i*i)%p] = true
+			}
+
+			for i := int64(0); i < p; i++ {
+				e, err := NewElement(big.NewInt(i), prime)
+				require.NoError(t, err)
+
+				expLegendre := -1
+				if i == 0 {
+					expLegendre = 0
+				} else if squares[i] {
+					expLegendre = 1
+				}
+				require.Equal(t, expLegendre, e.Legendre())
+				require.Equal(t, squares[i], e.IsSquare())
+
+				r, err := e.Sqrt()
+				if !squares[i] {
+					require.ErrorIs(t, err, ErrNoSquareRoot)
+					continue
+				}
+				require.NoError(t, err)
+
+				r2, err := r.Mul(r)
+				require.NoError(t, err)
+				require.True(t, r2.Equal(e))
+
+				// The negation must be the other root.
+				n := r.Negate()
+				n2, err := n.Mul(n)
+				require.NoError(t, err)
+				require.True(t, n2.Equal(e))
+			}
+		})
+	}
+}
+
+// TestNegate tests the Negate method of Element.
+func TestNegate(t *testing.T) {
+	tests := []struct {
+		p   int64
+		n   int64
+		neg int64
+	}{
+		{p: 19, n: 0, neg: 0},
+		{p: 19, n: 1, neg: 18},
+		{p: 19, n: 18, neg: 1},
+		{p: 223, n: 105, neg: 118},
+	}
+
+	for _, test := range tests {
+		t.Run("", func(t *testing.T) {
+			e, err := NewElement(big.NewInt(test.n), big.NewInt(test.p))
+			require.NoError(t, err)
+
+			exp, err := NewElement(
+				big.NewInt(test.neg), big.NewInt(test.p),
+			)
+			require.NoError(t, err)
+
+			n := e.Negate()
+			require.True(t, exp.Equal(n))
+
+			sum, err := e.Add(n)
+			require.NoError(t, err)
+			require.True(t, sum.IsZero())
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package finitefield
+
+import (
+	"crypto/rand"
+	"math"
+	"math/big"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The tests in this file implement a small statistical timing leakage detector
+// in the style of dudect (https://eprint.iacr.org/2016/1123.pdf). An operation
+// is timed on two classes of inputs: a fixed secret and random secrets. The
+// classes are interleaved randomly so that drift in the environment affects
+// both equally. Welch's t-test is then used to decide whether the two timing
+// distributions differ. A large |t| means that the running time depends on the
+// secret.
+//
+// The measurements are sensitive to the load on the machine so the tests are
+// only run when the DUDECT environment variable is set to 1:
+//
+//	DUDECT=1 go test -run Timing ./finitefield
+
+const (
+	// timingSamples is the number of measurements taken per operation.
+	timingSamples = 20000
+
+	// timingThreshold is the |t| value above which an operation is
+	// considered to leak. This is the value that dudect uses to declare
+	// that a leak has definitely been found.
+	timingThreshold = 10
+
+	// timingEnv is the environment variable that enables the timing tests.
+	timingEnv = "DUDECT"
+)
+
+// welch accumulates the running mean and variance of the two classes of
+// measurements.
+type welch struct {
+	n, mean, m2 [2]float64
+}
+
+// push adds a measurement to the given class.
+func (w *welch) push(class int, x float64) {
+	w.n[class]++
+	delta := x - w.mean[class]
+	w.mean[class] += delta / w.n[class]
+	w.m2[class] += delta * (x - w.mean[class])
+}
+
+// t returns Welch's t statistic for the two classes.
+func (w *welch) t() float64 {
+	v0 := w.m2[0] / (w.n[0] - 1)
+	v1 := w.m2[1] / (w.n[1] - 1)
+
+	return (w.mean[0] - w.mean[1]) / math.Sqrt(v0/w.n[0]+v1/w.n[1])
+}
+
+// measureLeakage times op on inputs from the two classes and returns the
+// largest |t| statistic found. The class 0 inputs are produced by fixed and the
+// class 1 inputs by random. Like dudect, the t statistic is computed both for
+// all the measurements and for the measurements below a few percentiles, since
+// removing the long tail of measurements that were interrupted by the scheduler
+// or the garbage collector makes the test far more sensitive.
+func measureLeakage(t *testing.T, fixed, random func() interface{},
+	op func(interface{})) float64 {
+
+	classes := make([]byte, timingSamples)
+	_, err := rand.Read(classes)
+	require.NoError(t, err)
+
+	inputs := make([]interface{}, timingSamples)
+	for i := range inputs {
+		classes[i] &= 1
+		if classes[i] == 0 {
+			inputs[i] = fixed()
+		} else {
+			inputs[i] = random()
+		}
+	}
+
+	// Warm up so that the first measurements are not skewed.
+	for i := 0; i < 100; i++ {
+		op(inputs[i])
+	}
+
+	times := make([]float64, timingSamples)
+	for i, in := range inputs {
+		start := time.Now()
+		op(in)
+		times[i] = float64(time.Since(start))
+	}
+
+	sorted := append([]float64(nil), times...)
+	sort.Float64s(sorted)
+
+	var maxT float64
+	for _, pct := range []float64{1, 0.9, 0.75, 0.5} {
+		cutoff := sorted[int(pct*float64(len(sorted)-1))]
+
+		var w welch
+		for i, x := range times {
+			if x <= cutoff {
+				w.push(int(classes[i]), x)
+			}
+		}
+
+		maxT = math.Max(maxT, math.Abs(w.t()))
+	}
+
+	return maxT
+}
+
+// timingTestField returns the secp256k1 group order which is the field that
+// the secret operations in the signing code are done in.
+func timingTestField(t *testing.T) *big.Int {
+	n, ok := new(big.Int).SetString(
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141",
+		16,
+	)
+	require.True(t, ok)
+
+	return n
+}
+
+// randomSecret returns a random SecretElement in the field of order p.
+func randomSecret(t testing.TB, p *big.Int) *SecretElement {
+	b := make([]byte, 64)
+	_, err := rand.Read(b)
+	require.NoError(t, err)
+
+	return NewSecretElementFromBytes(b, p)
+}
+
+// skipUnlessTiming skips the test unless the timing tests have been enabled
+// with the DUDECT environment variable.
+func skipUnlessTiming(t *testing.T) {
+	t.Helper()
+
+	if os.Getenv(timingEnv) != "1" {
+		t.Skipf("skipping timing test, set %s=1 to run it", timingEnv)
+	}
+}
+
+// TestTimingHarness makes sure that the timing harness is able to detect a
+// known leak. Multiplying by zero with math/big is much faster than
+// multiplying by a random number.
+func TestTimingHarness(t *testing.T) {
+	skipUnlessTiming(t)
+
+	p := timingTestField(t)
+	x := randomSecret(t, p).Element()
+
+	fixed := func() interface{} {
+		return &Element{Num: new(big.Int), P: p}
+	}
+	random := func() interface{} {
+		return randomSecret(t, p).Element()
+	}
+
+	tStat := measureLeakage(t, fixed, random, func(in interface{}) {
+		_, _ = x.Mul(in.(*Element))
+	})
+
+	require.Greater(t, tStat, float64(timingThreshold))
+}
+
+// TestSecretElementTiming checks that the SecretElement operations used on
+// secrets do not have secret dependent timing.
+func TestSecretElementTiming(t *testing.T) {
+	skipUnlessTiming(t)
+
+	p := timingTestField(t)
+	x := randomSecret(t, p)
+
+	// The fixed class is zero which is the value most likely to trigger
+	// shortcuts in variable time code.
+	fixed := func() interface{} {
+		return NewSecretElementFromBytes(nil, p)
+	}
+	random := func() interface{} {
+		return randomSecret(t, p)
+	}
+
+	fixedBytes := func() interface{} {
+		return make([]byte, 32)
+	}
+	randomBytes := func() interface{} {
+		b := make([]byte, 32)
+		_, err := rand.Read(b)
+		require.NoError(t, err)
+
+		return b
+	}
+
+	tests := []struct {
+		name          string
+		fixed, random func() interface{}
+		op            func(interface{})
+	}{
+		{
+			name:   "mul",
+			fixed:  fixed,
+			random: random,
+			op: func(in interface{}) {
+				_, _ = x.Mul(in.(*SecretElement))
+			},
+		},
+		{
+			name:   "add",
+			fixed:  fixed,
+			random: random,
+			op: func(in interface{}) {
+				_, _ = x.Add(in.(*SecretElement))
+			},
+		},
+		{
+			name:   "cond negate",
+			fixed:  fixed,
+			random: random,
+			op: func(in interface{}) {
+				in.(*SecretElement).CondNegate(1)
+			},
+		},
+		{
+			name:   "inverse",
+			fixed:  fixed,
+			random: random,
+			op: func(in interface{}) {
+				in.(*SecretElement).Inverse()
+			},
+		},
+		{
+			name:   "from bytes",
+			fixed:  fixedBytes,
+			random: randomBytes,
+			op: func(in interface{}) {
+				NewSecretElementFromBytes(in.([]byte), p)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			tStat := measureLeakage(t, test.fixed, test.random, test.op)
+			require.Less(t, tStat, float64(timingThreshold))
+		})
+	}
+}
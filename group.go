@@ -0,0 +1,282 @@
+package schnorr
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ellemouton/schnorr/ellipticcurve"
+	"github.com/ellemouton/schnorr/finitefield"
+	"github.com/ellemouton/schnorr/secp256k1"
+)
+
+// maxOrderBits is the largest order, in bits, of a Group that can be used.
+// Challenges and nonces are derived from 32 byte tagged hashes, and the aux
+// randomness is xored into the 32 byte encoding of the secret key.
+const maxOrderBits = 256
+
+var (
+	// ErrNoGenerator is returned by NewGroup for a curve without a
+	// generator.
+	ErrNoGenerator = errors.New("curve has no generator")
+
+	// ErrGroupTooLarge is returned by NewGroup for a curve whose generator
+	// has an order of more than 256 bits.
+	ErrGroupTooLarge = errors.New("group order must be at most 256 bits")
+
+	// ErrNotInGroup is returned when a point is on the curve of a Group
+	// but not in the subgroup generated by its generator, which can only
+	// happen for curves with a cofactor.
+	ErrNotInGroup = errors.New("point is not in the group")
+)
+
+// Group is a group of prime order that Schnorr signatures, and MuSig2 on top of
+// them, can be computed in. It is the subgroup generated by the generator of
+// a short Weierstrass curve. All the signing and verification code goes
+// through a Group, and every PublicKey remembers the Group it belongs to.
+//
+// Secp256k1, which gives BIP340 signatures, is the Group used by all the
+// functions that do not take one. Any other curve with a generator, such as
+// P-256 or one of the toy curves of the ellipticcurve package, can be turned
+// into a Group with NewGroup. The BIP340 tagged hashes are used whatever the
+// Group is.
+type Group interface {
+	// Generator returns the generator G of the Group. Its curve is the
+	// curve that all the points of the Group are on.
+	Generator() *ellipticcurve.Point
+
+	// BaseField returns the field that the coordinates of the points are
+	// in. Its fixed-width encoding is the encoding of x-only public keys.
+	BaseField() *finitefield.Field
+
+	// ScalarField returns the field of order N, the order of the Group.
+	// Its fixed-width encoding is the encoding of Scalars.
+	ScalarField() *finitefield.Field
+
+	// LiftX returns the point with the given X coordinate and an even Y
+	// coordinate, or ellipticcurve.ErrPointNotOnCurve if there is none.
+	// ErrNotInGroup is returned if the point is not in the Group.
+	LiftX(x *finitefield.Element) (*ellipticcurve.Point, error)
+
+	// ScalarBaseMult returns k*G. It must run in constant time since k may
+	// be secret.
+	ScalarBaseMult(k *Scalar) *ellipticcurve.Point
+
+	// ScalarMult returns k*p. It is only used with public scalars.
+	ScalarMult(p *ellipticcurve.Point, k *Scalar) *ellipticcurve.Point
+
+	// MultiScalarMul returns the sum of the points each multiplied by the
+	// Scalar at the same index. It is only used with public scalars.
+	MultiScalarMul(points []*ellipticcurve.Point,
+		scalars []*Scalar) (*ellipticcurve.Point, error)
+}
+
+// Secp256k1 is the Group generated by the secp256k1 generator. Its
+// multiplications are done with the precomputed tables and the GLV
+// endomorphism of the secp256k1 package.
+var Secp256k1 Group = &secp256k1Group{
+	curveGroup: curveGroup{
+		curve:       secp256k1.Curve,
+		baseField:   secp256k1.BaseField,
+		scalarField: secp256k1.ScalarField,
+	},
+}
+
+// NewGroup returns the Group generated by the generator of the given curve.
+// ErrNoGenerator is returned if the curve does not have a generator, and an
+// error is also returned if the order of the generator is not prime or is
+// longer than 256 bits.
+//
+// If the curve has a cofactor other than one, not every point on it is in the
+// Group. The returned Group then checks that N*P is the point at infinity for
+// every point P that it parses, and rejects the points for which it is not with
+// ErrNotInGroup.
+//
+// NOTE: ScalarBaseMult, the only multiplication of the Group that takes secret
+// Scalars, runs in constant time with ellipticcurve.Point.MulSecret. It is
+// much slower than the one of Secp256k1. The other multiplications only take
+// public Scalars and use the variable time math/big based arithmetic of
+// ellipticcurve.Point.
+func NewGroup(c *ellipticcurve.Curve) (Group, error) {
+	if c.G() == nil {
+		return nil, ErrNoGenerator
+	}
+
+	if c.N().BitLen() > maxOrderBits {
+		return nil, ErrGroupTooLarge
+	}
+
+	scalarField, err := finitefield.NewField(c.N())
+	if err != nil {
+		return nil, err
+	}
+
+	baseField, err := finitefield.NewField(c.A.P)
+	if err != nil {
+		return nil, err
+	}
+
+	return &curveGroup{
+		curve:         c,
+		baseField:     baseField,
+		scalarField:   scalarField,
+		checkSubgroup: c.H().Cmp(big.NewInt(1)) != 0,
+	}, nil
+}
+
+// curveGroup is a Group whose arithmetic is done with ellipticcurve.Point.
+type curveGroup struct {
+	curve       *ellipticcurve.Curve
+	baseField   *finitefield.Field
+	scalarField *finitefield.Field
+
+	// checkSubgroup is true if the curve has a cofactor, in which case
+	// the points that are parsed must be checked to be in the subgroup
+	// generated by G.
+	checkSubgroup bool
+}
+
+// Generator returns the generator G of the Group.
+//
+// NOTE: this is part of the Group interface.
+func (g *curveGroup) Generator() *ellipticcurve.Point {
+	return g.curve.G()
+}
+
+// BaseField returns the field that the coordinates of the points are in.
+//
+// NOTE: this is part of the Group interface.
+func (g *curveGroup) BaseField() *finitefield.Field {
+	return g.baseField
+}
+
+// ScalarField returns the field of order N.
+//
+// NOTE: this is part of the Group interface.
+func (g *curveGroup) ScalarField() *finitefield.Field {
+	return g.scalarField
+}
+
+// LiftX returns the point with the given X coordinate and an even Y
+// coordinate.
+//
+// NOTE: this is part of the Group interface.
+func (g *curveGroup) LiftX(x *finitefield.Element) (*ellipticcurve.Point,
+	error) {
+
+	// This is the SEC1 compressed encoding of the point with an even Y
+	// coordinate.
+	b := append([]byte{0x02}, x.Bytes()...)
+
+	p, err := ellipticcurve.ParsePoint(b, g.curve)
+	if err != nil {
+		return nil, err
+	}
+
+	if g.checkSubgroup && !p.ScalarMult(g.curve.N()).IsInfinity {
+		return nil, ErrNotInGroup
+	}
+
+	return p, nil
+}
+
+// ScalarBaseMult returns k*G in constant time.
+//
+// NOTE: this is part of the Group interface.
+func (g *curveGroup) ScalarBaseMult(k *Scalar) *ellipticcurve.Point {
+	return g.curve.G().MulSecret(k.Bytes())
+}
+
+// ScalarMult returns k*p.
+//
+// NOTE: this is part of the Group interface.
+func (g *curveGroup) ScalarMult(p *ellipticcurve.Point,
+	k *Scalar) *ellipticcurve.Point {
+
+	return p.ScalarMult(k.BigInt())
+}
+
+// MultiScalarMul returns the sum of the points each multiplied by the Scalar at
+// the same index.
+//
+// NOTE: this is part of the Group interface.
+func (g *curveGroup) MultiScalarMul(points []*ellipticcurve.Point,
+	scalars []*Scalar) (*ellipticcurve.Point, error) {
+
+	ks := make([]*big.Int, len(scalars))
+	for i, k := range scalars {
+		ks[i] = k.BigInt()
+	}
+
+	return ellipticcurve.MultiScalarMul(points, ks)
+}
+
+// secp256k1Group is the Group of the secp256k1 curve. It overrides the
+// multiplications of curveGroup with the faster, and in the case of
+// ScalarBaseMult constant time, ones of the secp256k1 package.
+type secp256k1Group struct {
+	curveGroup
+}
+
+// ScalarBaseMult returns k*G in constant time.
+//
+// NOTE: this is part of the Group interface.
+func (g *secp256k1Group) ScalarBaseMult(k *Scalar) *ellipticcurve.Point {
+	return secp256k1.ScalarBaseMult(toSecp256k1Scalar(k)).Point
+}
+
+// ScalarMult returns k*p. If p equals G, by value rather than by pointer, the
+// precomputed table of G is used.
+//
+// NOTE: this is part of the Group interface.
+func (g *secp256k1Group) ScalarMult(p *ellipticcurve.Point,
+	k *Scalar) *ellipticcurve.Point {
+
+	if p.Equal(secp256k1.G.Point) {
+		s := toSecp256k1Scalar(k)
+
+		return secp256k1.ScalarBaseMultVartime(s).Point
+	}
+
+	return (&secp256k1.Point{Point: p}).Mul(k.BigInt()).Point
+}
+
+// MultiScalarMul returns the sum of the points each multiplied by the Scalar at
+// the same index. See secp256k1.MultiScalarMul.
+//
+// NOTE: this is part of the Group interface.
+func (g *secp256k1Group) MultiScalarMul(points []*ellipticcurve.Point,
+	scalars []*Scalar) (*ellipticcurve.Point, error) {
+
+	ps := make([]*secp256k1.Point, len(points))
+	for i, p := range points {
+		ps[i] = &secp256k1.Point{Point: p}
+	}
+
+	ks := make([]*secp256k1.Scalar, len(scalars))
+	for i, k := range scalars {
+		ks[i] = toSecp256k1Scalar(k)
+	}
+
+	res, err := secp256k1.MultiScalarMul(ps, ks)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Point, nil
+}
+
+// toSecp256k1Scalar converts the Scalar, which must be a Scalar of Secp256k1,
+// to a secp256k1.Scalar in constant time. Both types wrap a SecretElement of
+// secp256k1.ScalarField so the value is already reduced and the conversion
+// can't fail.
+func toSecp256k1Scalar(k *Scalar) *secp256k1.Scalar {
+	var b [secp256k1.ScalarBytesLen]byte
+	copy(b[:], k.Bytes())
+
+	return secp256k1.ScalarFromBytesReduce(b)
+}
+
+// sameGroup returns true if the two Groups have the same generator.
+func sameGroup(a, b Group) bool {
+	return a == b || a.Generator().Equal(b.Generator())
+}
@@ -0,0 +1,292 @@
+package schnorr
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ellemouton/schnorr/ellipticcurve"
+	"github.com/ellemouton/schnorr/finitefield"
+	"github.com/ellemouton/schnorr/p256"
+	"github.com/ellemouton/schnorr/secp256k1"
+	"github.com/stretchr/testify/require"
+)
+
+// toyGroup returns the Group of the curve y^2 = x^3 + 3 over F_10099. The
+// curve has 9967 points, which is prime, so (1, 2) generates all of them.
+func toyGroup(t *testing.T) Group {
+	f, err := finitefield.NewField(big.NewInt(10099))
+	require.NoError(t, err)
+
+	c, err := ellipticcurve.NewCurve(f.FromInt(0), f.FromInt(3))
+	require.NoError(t, err)
+
+	c, err = c.WithGenerator(
+		f.FromInt(1), f.FromInt(2), big.NewInt(9967), big.NewInt(1),
+	)
+	require.NoError(t, err)
+
+	g, err := NewGroup(c)
+	require.NoError(t, err)
+
+	return g
+}
+
+// cofactorGroup returns the Group of the curve y^2 = x^3 + 2x + 11 over
+// F_10007. The curve has 10174 = 2*5087 points and (4, 1820) generates the
+// subgroup of order 5087.
+func cofactorGroup(t *testing.T) Group {
+	f, err := finitefield.NewField(big.NewInt(10007))
+	require.NoError(t, err)
+
+	c, err := ellipticcurve.NewCurve(f.FromInt(2), f.FromInt(11))
+	require.NoError(t, err)
+
+	c, err = c.WithGenerator(
+		f.FromInt(4), f.FromInt(1820), big.NewInt(5087), big.NewInt(2),
+	)
+	require.NoError(t, err)
+
+	g, err := NewGroup(c)
+	require.NoError(t, err)
+
+	return g
+}
+
+// testGroups returns the Groups that the generic tests are run over.
+func testGroups(t *testing.T) map[string]Group {
+	p256Group, err := NewGroup(p256.Curve)
+	require.NoError(t, err)
+
+	return map[string]Group{
+		"secp256k1": Secp256k1,
+		"p256":      p256Group,
+		"toy":       toyGroup(t),
+		"cofactor":  cofactorGroup(t),
+	}
+}
+
+// TestNewGroup checks that NewGroup only accepts curves with a generator of
+// prime order.
+func TestNewGroup(t *testing.T) {
+	f, err := finitefield.NewField(big.NewInt(223))
+	require.NoError(t, err)
+
+	c, err := ellipticcurve.NewCurve(f.FromInt(0), f.FromInt(7))
+	require.NoError(t, err)
+
+	_, err = NewGroup(c)
+	require.ErrorIs(t, err, ErrNoGenerator)
+
+	// (47, 71) has order 21.
+	c, err = c.WithGenerator(
+		f.FromInt(47), f.FromInt(71), big.NewInt(21), big.NewInt(2),
+	)
+	require.NoError(t, err)
+
+	_, err = NewGroup(c)
+	require.ErrorIs(t, err, finitefield.ErrNotPrime)
+}
+
+// TestSignInGroups signs and verifies messages in each of the test Groups and
+// checks the encodings of the keys and signatures.
+func TestSignInGroups(t *testing.T) {
+	msg := bytes.Repeat([]byte{0x42}, 32)
+	aux := bytes.Repeat([]byte{0x24}, 32)
+
+	for name, g := range testGroups(t) {
+		g := g
+		t.Run(name, func(t *testing.T) {
+			var (
+				pks  []*PublicKey
+				sigs []*Signature
+				ms   [][]byte
+			)
+			for i := uint64(1); i <= 4; i++ {
+				sk, err := PrivateKeyFromScalarInGroup(
+					g, NewScalar(g, 1000*i+7),
+				)
+				require.NoError(t, err)
+
+				m := append([]byte{}, msg...)
+				m[0] = byte(i)
+
+				sig, err := sk.Sign(m, aux)
+				require.NoError(t, err)
+				require.NoError(t, sig.Verify(sk.PubKey, m))
+
+				// The signature does not verify for another
+				// message.
+				require.Error(t, sig.Verify(sk.PubKey, msg))
+
+				parsed, err := NewSignatureFromBytesInGroup(
+					g, sig.BytesInGroup(),
+				)
+				require.NoError(t, err)
+				require.Equal(
+					t, sig.BytesInGroup(),
+					parsed.BytesInGroup(),
+				)
+
+				xOnly, err := ParseXOnlyPubKeyInGroup(
+					g, sk.PubKey.XOnlyBytes(),
+				)
+				require.NoError(t, err)
+				require.True(t, xOnly.HasEvenY())
+				require.NoError(t, parsed.Verify(xOnly, m))
+
+				plain, err := ParsePlainPubKeyInGroup(
+					g, sk.PubKey.PlainBytes(),
+				)
+				require.NoError(t, err)
+				require.True(
+					t, plain.Point.Equal(sk.PubKey.Point),
+				)
+
+				pks = append(pks, sk.PubKey)
+				sigs = append(sigs, sig)
+				ms = append(ms, m)
+			}
+
+			require.NoError(t, BatchVerify(pks, ms, sigs))
+
+			ms[0] = ms[1]
+			require.Error(t, BatchVerify(pks, ms, sigs))
+		})
+	}
+}
+
+// TestGroupMatchesSecp256k1 checks that signing with the generic Group of the
+// secp256k1 curve gives the same signatures as Secp256k1, and that signatures
+// are not accepted for keys of another Group.
+func TestGroupMatchesSecp256k1(t *testing.T) {
+	g, err := NewGroup(secp256k1.Curve)
+	require.NoError(t, err)
+
+	msg := bytes.Repeat([]byte{0x01}, 32)
+	aux := bytes.Repeat([]byte{0x02}, 32)
+
+	sk1, err := PrivateKeyFromScalarInGroup(
+		Secp256k1, NewScalar(Secp256k1, 12345),
+	)
+	require.NoError(t, err)
+
+	sk2, err := PrivateKeyFromScalarInGroup(g, NewScalar(g, 12345))
+	require.NoError(t, err)
+
+	sig1, err := sk1.Sign(msg, aux)
+	require.NoError(t, err)
+
+	sig2, err := sk2.Sign(msg, aux)
+	require.NoError(t, err)
+
+	require.Equal(t, sig1.Bytes(), sig2.Bytes())
+	require.NoError(t, sig2.Verify(sk1.PubKey, msg))
+
+	p256Group, err := NewGroup(p256.Curve)
+	require.NoError(t, err)
+
+	sk3, err := PrivateKeyFromScalarInGroup(
+		p256Group, NewScalar(p256Group, 12345),
+	)
+	require.NoError(t, err)
+
+	err = sig1.Verify(sk3.PubKey, msg)
+	require.ErrorIs(t, err, ErrGroupMismatch)
+}
+
+// TestSecp256k1Defaults checks that the functions that do not take a Group
+// keep working with the secp256k1 types, and that a PublicKey without a Group
+// is in Secp256k1.
+func TestSecp256k1Defaults(t *testing.T) {
+	d, err := secp256k1.ScalarFromBytes(bytes.Repeat([]byte{0x07}, 32))
+	require.NoError(t, err)
+
+	sk, err := PrivateKeyFromScalar(d)
+	require.NoError(t, err)
+	require.Equal(t, Secp256k1, sk.PubKey.Group())
+
+	pk := NewPublicKey(secp256k1.ScalarBaseMult(d))
+	require.True(t, pk.Equal(sk.PubKey))
+
+	// A PublicKey built without a Group is a secp256k1 one.
+	zero := &PublicKey{Point: sk.PubKey.Point}
+	require.Equal(t, Secp256k1, zero.Group())
+	require.True(t, zero.Double().Equal(sk.PubKey.Double()))
+
+	msg := bytes.Repeat([]byte{0x03}, 32)
+	sig, err := sk.Sign(msg, msg)
+	require.NoError(t, err)
+	require.NoError(t, sig.Verify(zero, msg))
+
+	b := sig.Bytes()
+	require.Equal(t, b[:], sig.BytesInGroup())
+
+	// The fixed size encoding is only for Groups with 64 byte signatures.
+	g := toyGroup(t)
+	toySk, err := PrivateKeyFromScalarInGroup(g, NewScalar(g, 5))
+	require.NoError(t, err)
+
+	toySig, err := toySk.Sign(msg, msg)
+	require.NoError(t, err)
+	require.Len(t, toySig.BytesInGroup(), 4)
+	require.Panics(t, func() { toySig.Bytes() })
+}
+
+// TestScalarNotInGroup checks that Scalars of another Group are rejected with
+// an error rather than a panic.
+func TestScalarNotInGroup(t *testing.T) {
+	g := toyGroup(t)
+
+	s := NewScalar(Secp256k1, 5)
+	require.True(t, s.InGroup(Secp256k1))
+	require.False(t, s.InGroup(g))
+
+	_, err := PrivateKeyFromScalarInGroup(g, s)
+	require.ErrorIs(t, err, ErrScalarNotInGroup)
+
+	sk, err := PrivateKeyFromScalarInGroup(g, NewScalar(g, 5))
+	require.NoError(t, err)
+
+	msg := bytes.Repeat([]byte{0x05}, 32)
+	sig, err := sk.Sign(msg, msg)
+	require.NoError(t, err)
+
+	sig.S = s
+	require.ErrorIs(t, sig.Verify(sk.PubKey, msg), ErrScalarNotInGroup)
+	require.ErrorIs(
+		t, BatchVerify([]*PublicKey{sk.PubKey}, [][]byte{msg},
+			[]*Signature{sig}),
+		ErrScalarNotInGroup,
+	)
+
+	sk.D = s
+	_, err = sk.Sign(msg, msg)
+	require.ErrorIs(t, err, ErrScalarNotInGroup)
+}
+
+// TestCofactorGroup checks that a Group of a curve with a cofactor only accepts
+// the points of the subgroup generated by its generator.
+func TestCofactorGroup(t *testing.T) {
+	g := cofactorGroup(t)
+	f := g.BaseField()
+
+	// (4, 1820) is the generator and (3, 5136) is on the curve but has
+	// an order of 2*5087.
+	_, err := ParseXOnlyPubKeyInGroup(g, f.FromInt(4).Bytes())
+	require.NoError(t, err)
+
+	_, err = ParseXOnlyPubKeyInGroup(g, f.FromInt(3).Bytes())
+	require.ErrorIs(t, err, ErrNotInGroup)
+
+	for _, tag := range []byte{0x02, 0x03} {
+		b := append([]byte{tag}, f.FromInt(4).Bytes()...)
+		pk, err := ParsePlainPubKeyInGroup(g, b)
+		require.NoError(t, err)
+		require.Equal(t, b, pk.PlainBytes())
+
+		b = append([]byte{tag}, f.FromInt(3).Bytes()...)
+		_, err = ParsePlainPubKeyInGroup(g, b)
+		require.ErrorIs(t, err, ErrNotInGroup)
+	}
+}
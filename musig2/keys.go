@@ -4,8 +4,6 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/ellemouton/schnorr"
-	"github.com/ellemouton/schnorr/secp256k1"
-	"math/big"
 	"sort"
 )
 
@@ -14,11 +12,7 @@ const (
 	KeyAggCoefficientTag = "KeyAgg coefficient"
 )
 
-var (
-	ErrPointAtInfinity = fmt.Errorf("point at infinity")
-
-	negOne = big.NewInt(-1)
-)
+var ErrPointAtInfinity = fmt.Errorf("point at infinity")
 
 // KeySort implements the Musig2 KeySort algorithm. It sorts the given set of
 // public keys in lexographical order of their plain pub key bytes.
@@ -37,13 +31,13 @@ type KeyGenCtx struct {
 	Q *schnorr.PublicKey
 
 	// TAcc is the accumulated tweak (0 <= tacc < n)
-	TAcc *big.Int
+	TAcc *schnorr.Scalar
 
 	// GAcc is 1 or -1 mod n. It is used to track the accumulated sign
 	// flipping. It indicates whether Q needs to be negated to produce the
 	// final x-only result. In other words, it indicates if the private key
 	// needs to be negated.
-	GAcc *big.Int
+	GAcc *schnorr.Scalar
 }
 
 // ApplyTweak applies the given Tweak to the KeyGenCtx.
@@ -51,14 +45,21 @@ func (ctx *KeyGenCtx) ApplyTweak(tweak *Tweak) error {
 	// If the tweak is x-only and the current Q has a negative Y, then we
 	// set gAcc to -1%n so that we remember to negate the private key
 	// correctly at signing time.
-	gAcc := big.NewInt(1)
+	g := ctx.Q.Group()
+	if !tweak.T.InGroup(g) {
+		return schnorr.ErrScalarNotInGroup
+	}
+
+	gAcc := schnorr.NewScalar(g, 1)
 	if tweak.Xonly && !ctx.Q.HasEvenY() {
-		gAcc.Mod(negOne, secp256k1.N)
+		gAcc = gAcc.Negate()
 	}
 
 	// Project the tweak only the curve.
 	// 	T = t*G
-	T := schnorr.NewPublicKey(secp256k1.G.Mul(tweak.T))
+	T := schnorr.NewPublicKeyInGroup(
+		g, g.ScalarMult(g.Generator(), tweak.T),
+	)
 
 	// Q = g*Q + t*G
 	//
@@ -74,8 +75,7 @@ func (ctx *KeyGenCtx) ApplyTweak(tweak *Tweak) error {
 
 	// Add the tweak to the accumulated tweak.
 	// Tacc = T + g*Tacc
-	tAcc := new(big.Int).Add(tweak.T, new(big.Int).Mul(gAcc, ctx.TAcc))
-	tAcc.Mod(tAcc, secp256k1.N)
+	tAcc := tweak.T.Add(gAcc.Mul(ctx.TAcc))
 
 	ctx.Q = Q
 	ctx.TAcc = tAcc
@@ -93,8 +93,9 @@ func KeyAgg(pks []*schnorr.PublicKey) (*KeyGenCtx, error) {
 		return nil, fmt.Errorf("could not get second key: %w", err)
 	}
 
-	// Initialise a "zero" value Pub key.
-	Q := schnorr.NewInfinityPubKey()
+	// Initialise a "zero" value Pub key in the Group of the keys.
+	g := pks[0].Group()
+	Q := schnorr.NewInfinityPubKeyInGroup(g)
 
 	// Iterate over all the pub keys, calculate the coefficient for each
 	// and add the pub key multiplied by the coefficient to the aggregate
@@ -123,8 +124,8 @@ func KeyAgg(pks []*schnorr.PublicKey) (*KeyGenCtx, error) {
 	// GAcc starts as
 	return &KeyGenCtx{
 		Q:    Q,
-		TAcc: big.NewInt(0),
-		GAcc: big.NewInt(1),
+		TAcc: schnorr.NewScalar(g, 0),
+		GAcc: schnorr.NewScalar(g, 1),
 	}, nil
 }
 
@@ -147,25 +148,23 @@ func getSecondKey(pks []*schnorr.PublicKey) ([]byte, error) {
 // keyAggCoeffInternal computes the coefficient that will be applied to pk when
 // aggregating the pks.
 func keyAggCoeffInternal(pks []*schnorr.PublicKey, pk *schnorr.PublicKey,
-	pk2 []byte) *big.Int {
+	pk2 []byte) *schnorr.Scalar {
 
 	if bytes.Equal(pk.PlainBytes(), pk2) {
-		return big.NewInt(1)
+		return schnorr.NewScalar(pk.Group(), 1)
 	}
 
 	l := hashKeys(pks)
+	b := append(l[:], pk.PlainBytes()...)
+	coeff := schnorr.TaggedHash(KeyAggCoefficientTag, b)
 
-	b := make([]byte, 32+schnorr.PlainPubKeyBytesLen)
-	copy(b[:32], l[:])
-	copy(b[32:], pk.PlainBytes())
-
-	return schnorr.IntFromBytes(schnorr.TaggedHash(KeyAggCoefficientTag, b))
+	return schnorr.ScalarFromBytesReduce(pk.Group(), coeff[:])
 }
 
 // keyAggCoeff computes the coefficient that will be applied to pk when
 // aggregating the pks.
-func keyAggCoeff(pks []*schnorr.PublicKey, pk *schnorr.PublicKey) (*big.Int,
-	error) {
+func keyAggCoeff(pks []*schnorr.PublicKey, pk *schnorr.PublicKey) (
+	*schnorr.Scalar, error) {
 
 	var found bool
 	for _, p := range pks {
@@ -189,12 +188,9 @@ func keyAggCoeff(pks []*schnorr.PublicKey, pk *schnorr.PublicKey) (*big.Int,
 
 // hashKeys computes the hash of the pk list.
 func hashKeys(pks []*schnorr.PublicKey) [32]byte {
-	data := make([]byte, len(pks)*schnorr.PlainPubKeyBytesLen)
-	for i, pk := range pks {
-		offset1 := i * schnorr.PlainPubKeyBytesLen
-		offset2 := offset1 + schnorr.PlainPubKeyBytesLen
-
-		copy(data[offset1:offset2], pk.PlainBytes())
+	var data []byte
+	for _, pk := range pks {
+		data = append(data, pk.PlainBytes()...)
 	}
 
 	return schnorr.TaggedHash(KeyAggListTag, data)
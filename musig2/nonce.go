@@ -17,8 +17,6 @@ const (
 	PubNonceLen = 66 // 33 + 33
 )
 
-var zeroByteVector = bytes.Repeat([]byte{0x00}, 33)
-
 // NonceGenOption defines the signature of a functional option that can be used
 // to modify the NonceGen function.
 type NonceGenOption func(opts *nonceGenCfg)
@@ -102,35 +100,40 @@ func (s *SecNonce) GetPubNonce() *PubNonce {
 //
 //	k1 || k2 || pk
 func (s *SecNonce) Bytes() []byte {
-	res := make([]byte, SecNonceLen)
-
-	k1Bytes := s.k1.Bytes()
-	copy(res[:32], k1Bytes[:])
+	res := append(s.k1.D.Bytes(), s.k2.D.Bytes()...)
 
-	k2Bytes := s.k2.Bytes()
-	copy(res[32:64], k2Bytes[:])
-	copy(res[64:], s.pk.PlainBytes())
-
-	return res
+	return append(res, s.pk.PlainBytes()...)
 }
 
-// ParseSecNonce constructs a SecNonce from the given byte slice.
+// ParseSecNonce constructs a secp256k1 SecNonce from the given byte slice.
 func ParseSecNonce(b []byte) (*SecNonce, error) {
 	if len(b) != SecNonceLen {
 		return nil, fmt.Errorf("invalid sec nonce len")
 	}
 
-	k1, err := schnorr.ParsePrivKeyBytes(b[:32])
+	return ParseSecNonceInGroup(schnorr.Secp256k1, b)
+}
+
+// ParseSecNonceInGroup constructs a SecNonce of the given Group from the given
+// byte slice. The encodings of k1 and k2 are schnorr.ScalarLen bytes long and
+// the one of pk is that of ParsePlainPubKeyInGroup.
+func ParseSecNonceInGroup(g schnorr.Group, b []byte) (*SecNonce, error) {
+	sLen := schnorr.ScalarLen(g)
+	if len(b) != 2*sLen+1+g.BaseField().ByteLen() {
+		return nil, fmt.Errorf("invalid sec nonce len")
+	}
+
+	k1, err := schnorr.ParsePrivKeyBytesInGroup(g, b[:sLen])
 	if err != nil {
 		return nil, err
 	}
 
-	k2, err := schnorr.ParsePrivKeyBytes(b[32:64])
+	k2, err := schnorr.ParsePrivKeyBytesInGroup(g, b[sLen:2*sLen])
 	if err != nil {
 		return nil, err
 	}
 
-	pk, err := schnorr.ParsePlainPubKey(b[64:])
+	pk, err := schnorr.ParsePlainPubKeyInGroup(g, b[2*sLen:])
 	if err != nil {
 		return nil, err
 	}
@@ -149,28 +152,43 @@ type PubNonce struct {
 	R1, R2 *schnorr.PublicKey
 }
 
-// ParsePubNonce constructs a PubNonce from the given byte slice.
+// ParsePubNonce constructs a secp256k1 PubNonce from the given byte slice.
 func ParsePubNonce(b []byte) (*PubNonce, error) {
 	if len(b) != PubNonceLen {
 		return nil, fmt.Errorf("bad pub nonce len")
 	}
 
-	n1Bytes := b[:33]
-	n2Bytes := b[33:]
+	return ParsePubNonceInGroup(schnorr.Secp256k1, b)
+}
+
+// ParsePubNonceInGroup constructs a PubNonce of the given Group from the given
+// byte slice. Each nonce is either the encoding of ParsePlainPubKeyInGroup or
+// all zeros for the point at infinity.
+func ParsePubNonceInGroup(g schnorr.Group, b []byte) (*PubNonce, error) {
+	nLen := 1 + g.BaseField().ByteLen()
+	if len(b) != 2*nLen {
+		return nil, fmt.Errorf("bad pub nonce len")
+	}
+
+	n1Bytes := b[:nLen]
+	n2Bytes := b[nLen:]
 
-	R1 := schnorr.NewInfinityPubKey()
-	R2 := schnorr.NewInfinityPubKey()
+	R1 := schnorr.NewInfinityPubKeyInGroup(g)
+	R2 := schnorr.NewInfinityPubKeyInGroup(g)
 
-	var err error
+	var (
+		zeroByteVector = make([]byte, nLen)
+		err            error
+	)
 	if !bytes.Equal(n1Bytes, zeroByteVector) {
-		R1, err = schnorr.ParsePlainPubKey(n1Bytes)
+		R1, err = schnorr.ParsePlainPubKeyInGroup(g, n1Bytes)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	if !bytes.Equal(n2Bytes, zeroByteVector) {
-		R2, err = schnorr.ParsePlainPubKey(n2Bytes)
+		R2, err = schnorr.ParsePlainPubKeyInGroup(g, n2Bytes)
 		if err != nil {
 			return nil, err
 		}
@@ -183,23 +201,20 @@ func ParsePubNonce(b []byte) (*PubNonce, error) {
 }
 
 // Bytes serialises the given PubNonce.
-//   cbytes(p.R1) || cbytes(p.R2)
+//
+//	cbytes(p.R1) || cbytes(p.R2)
 func (p *PubNonce) Bytes() []byte {
-	res := make([]byte, PubNonceLen)
-
-	if p.R1.IsInfinity {
-		copy(res[:33], zeroByteVector)
-	} else {
-		copy(res[:33], p.R1.PlainBytes())
-	}
+	return append(nonceBytes(p.R1), nonceBytes(p.R2)...)
+}
 
-	if p.R2.IsInfinity {
-		copy(res[33:], zeroByteVector)
-	} else {
-		copy(res[33:], p.R2.PlainBytes())
+// nonceBytes returns the compressed encoding of the public nonce, or all zeros
+// if it is at infinity.
+func nonceBytes(r *schnorr.PublicKey) []byte {
+	if r.IsInfinity {
+		return make([]byte, 1+r.Group().BaseField().ByteLen())
 	}
 
-	return res
+	return r.PlainBytes()
 }
 
 // NonceGen generates a Nonce from the given set of inputs.
@@ -284,23 +299,35 @@ func makeNonce(i uint8, pk *schnorr.PublicKey, rand, aggPk, mPrefixed,
 	extraInLen := make([]byte, 4)
 	binary.BigEndian.PutUint32(extraInLen, uint32(len(extraIn)))
 
+	pkBytes := pk.PlainBytes()
+
 	hash := schnorr.TaggedHash(
 		NonceTag,
 		rand,
-		[]byte{0x21}, pk.PlainBytes(),
+		[]byte{byte(len(pkBytes))}, pkBytes,
 		[]byte{byte(len(aggPk))}, aggPk,
 		mPrefixed,
 		extraInLen, extraIn,
 		[]byte{i - 1},
 	)
 
-	return schnorr.PrivateKeyFromInt(schnorr.IntFromBytes(hash))
+	g := pk.Group()
+
+	return schnorr.PrivateKeyFromScalarInGroup(
+		g, schnorr.ScalarFromBytesReduce(g, hash[:]),
+	)
 }
 
 // NonceAgg aggregates the given set of PubNonces into a single PubNonce.
 func NonceAgg(pNonces []*PubNonce) *PubNonce {
+	g := schnorr.Secp256k1
+	if len(pNonces) > 0 {
+		g = pNonces[0].R1.Group()
+	}
+
 	nonces := []*schnorr.PublicKey{
-		schnorr.NewInfinityPubKey(), schnorr.NewInfinityPubKey(),
+		schnorr.NewInfinityPubKeyInGroup(g),
+		schnorr.NewInfinityPubKeyInGroup(g),
 	}
 
 	for j, _ := range nonces {
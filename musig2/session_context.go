@@ -3,8 +3,6 @@ package musig2
 import (
 	"fmt"
 	"github.com/ellemouton/schnorr"
-	"github.com/ellemouton/schnorr/secp256k1"
-	"math/big"
 )
 
 const NonceCoefTag = "MuSig/noncecoef"
@@ -41,9 +39,9 @@ func NewSessionContext(aggNonce *PubNonce, pks []*schnorr.PublicKey, msg []byte,
 // a PartialSig.
 type SigContext struct {
 	*KeyGenCtx
-	B *big.Int
+	B *schnorr.Scalar
 	R *schnorr.PublicKey
-	E *big.Int
+	E *schnorr.Scalar
 }
 
 // GetSigContext takes a SessionContext and computes all the values needed to
@@ -70,12 +68,14 @@ func (ctx *SessionContext) GetSigContext() (*SigContext, error) {
 	// AggPubNonce.
 	//
 	// b = H( R1 || R2 || P || m )
-	b := schnorr.IntFromBytes(schnorr.TaggedHash(
+	g := kgCtx.Q.Group()
+	bHash := schnorr.TaggedHash(
 		NonceCoefTag,
 		ctx.AggPubNonce.Bytes(),
 		kgCtx.Q.XOnlyBytes(),
 		ctx.Msg,
-	))
+	)
+	b := schnorr.ScalarFromBytesReduce(g, bHash[:])
 
 	// Calculate the final, single, Public nonce that will be used to
 	// construct the musig signature
@@ -83,17 +83,18 @@ func (ctx *SessionContext) GetSigContext() (*SigContext, error) {
 	// R = R1 + b*R2
 	R := ctx.AggPubNonce.R1.Add(ctx.AggPubNonce.R2.Mul(b))
 	if R.IsInfinity {
-		R = schnorr.NewPublicKey(secp256k1.G)
+		R = schnorr.NewPublicKeyInGroup(g, g.Generator())
 	}
 
 	// Finally, construct the e value that commits to the R, P and m values.
 	// e = H( R || P || m )
-	e := schnorr.IntFromBytes(schnorr.TaggedHash(
+	eHash := schnorr.TaggedHash(
 		schnorr.Bip340ChallengeTag,
 		R.XOnlyBytes(),
 		kgCtx.Q.XOnlyBytes(),
 		ctx.Msg,
-	))
+	)
+	e := schnorr.ScalarFromBytesReduce(g, eHash[:])
 
 	return &SigContext{
 		KeyGenCtx: kgCtx,
@@ -116,24 +117,35 @@ func (ctx *SessionContext) GetSigContext() (*SigContext, error) {
 //
 //	d' = (-d) + t
 type Tweak struct {
-	T     *big.Int
+	T     *schnorr.Scalar
 	Xonly bool
 }
 
-// NewTweak constructs a new Tweak from the given byte slice and tweak mode.
+// NewTweak constructs a new secp256k1 Tweak from the given byte slice and
+// tweak mode.
 func NewTweak(b []byte, xonly bool) (*Tweak, error) {
 	if len(b) != 32 {
 		return nil, fmt.Errorf("tweak must be 32 bytes")
 	}
 
-	var t big.Int
-	t.SetBytes(b)
-	if t.Cmp(secp256k1.N) >= 0 {
+	return NewTweakInGroup(schnorr.Secp256k1, b, xonly)
+}
+
+// NewTweakInGroup constructs a new Tweak of the given Group from the given
+// byte slice, which must be schnorr.ScalarLen bytes long, and tweak mode.
+func NewTweakInGroup(g schnorr.Group, b []byte, xonly bool) (*Tweak, error) {
+	if len(b) != schnorr.ScalarLen(g) {
+		return nil, fmt.Errorf("tweak must be %d bytes",
+			schnorr.ScalarLen(g))
+	}
+
+	t, err := schnorr.ScalarFromBytes(g, b)
+	if err != nil {
 		return nil, ErrTweakOutOfRange
 	}
 
 	return &Tweak{
-		T:     &t,
+		T:     t,
 		Xonly: xonly,
 	}, nil
 }
@@ -3,7 +3,7 @@ package musig2
 import (
 	"fmt"
 	"github.com/ellemouton/schnorr"
-	"github.com/ellemouton/schnorr/secp256k1"
+	"github.com/ellemouton/schnorr/ellipticcurve"
 	"math/big"
 )
 
@@ -16,36 +16,54 @@ const PartialSigLen = 32
 // of partial sig exchange, all participants will already know the public nonces
 // meaning that the PartialSig only needs to contain the s value.
 type PartialSig struct {
-	S *big.Int
+	S *schnorr.Scalar
 }
 
-// NewPartialSig constructs a new PartialSig from the given s.
+// NewPartialSig constructs a new secp256k1 PartialSig from the given s. An
+// error is returned if s is not in the range [0, N).
+//
+// Deprecated: use NewPartialSigFromScalar, which does not hold s in a variable
+// time big.Int.
 func NewPartialSig(s *big.Int) (*PartialSig, error) {
-	if s.Cmp(secp256k1.N) >= 0 {
+	if s.Sign() < 0 || s.BitLen() > 8*PartialSigLen {
 		return nil, fmt.Errorf("partial sig out of order bounds")
 	}
 
-	return &PartialSig{S: s}, nil
+	return ParsePartialSig(s.FillBytes(make([]byte, PartialSigLen)))
+}
+
+// NewPartialSigFromScalar constructs a new PartialSig from the given s.
+func NewPartialSigFromScalar(s *schnorr.Scalar) *PartialSig {
+	return &PartialSig{S: s}
 }
 
 // Bytes returns the serialised byte representation of a PartialSig.
 func (ps *PartialSig) Bytes() []byte {
-	var b [PartialSigLen]byte
-	ps.S.FillBytes(b[:])
-
-	return b[:]
+	return ps.S.Bytes()
 }
 
-// ParsePartialSig constructs a PartialSig from the given bytes slice.
+// ParsePartialSig constructs a secp256k1 PartialSig from the given bytes slice.
 func ParsePartialSig(b []byte) (*PartialSig, error) {
 	if len(b) != PartialSigLen {
 		return nil, fmt.Errorf("wrong len for partial sig")
 	}
 
-	var s big.Int
-	s.SetBytes(b)
+	return ParsePartialSigInGroup(schnorr.Secp256k1, b)
+}
+
+// ParsePartialSigInGroup constructs a PartialSig of the given Group from the
+// given byte slice, which must be schnorr.ScalarLen bytes long.
+func ParsePartialSigInGroup(g schnorr.Group, b []byte) (*PartialSig, error) {
+	if len(b) != schnorr.ScalarLen(g) {
+		return nil, fmt.Errorf("wrong len for partial sig")
+	}
+
+	s, err := schnorr.ScalarFromBytes(g, b)
+	if err != nil {
+		return nil, fmt.Errorf("partial sig out of order bounds")
+	}
 
-	return NewPartialSig(&s)
+	return NewPartialSigFromScalar(s), nil
 }
 
 // Sign produces a valid PartialSig for the given SessionContext, secret key
@@ -67,23 +85,18 @@ func Sign(ctx *SessionContext, sn *SecNonce, sk *schnorr.PrivateKey) (
 		return nil, err
 	}
 
+	group := signCtx.Q.Group()
+	if !sk.D.InGroup(group) || !sn.k1.D.InGroup(group) ||
+		!sn.k2.D.InGroup(group) {
+
+		return nil, schnorr.ErrScalarNotInGroup
+	}
+
 	// If the final schnorr nonce R has an odd Y, then we need to negate
 	// our secnonces.
-	k1, k2 := sn.k1, sn.k2
+	k1, k2 := sn.k1.D, sn.k2.D
 	if !signCtx.R.HasEvenY() {
-		k1, err = schnorr.PrivateKeyFromInt(
-			new(big.Int).Sub(secp256k1.N, k1.D),
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		k2, err = schnorr.PrivateKeyFromInt(
-			new(big.Int).Sub(secp256k1.N, k2.D),
-		)
-		if err != nil {
-			return nil, err
-		}
+		k1, k2 = k1.Negate(), k2.Negate()
 	}
 
 	// Get our original (pre coefficients) pub key.
@@ -100,35 +113,27 @@ func Sign(ctx *SessionContext, sn *SecNonce, sk *schnorr.PrivateKey) (
 		return nil, err
 	}
 
-	g := big.NewInt(1)
+	g := schnorr.NewScalar(group, 1)
 	if !signCtx.Q.HasEvenY() {
-		g.Mod(big.NewInt(-1), secp256k1.N)
+		g = g.Negate()
 	}
 
 	// Negate the private key if needed.
 	// 	d' = g * gacc * d
-	d := new(big.Int).Mul(new(big.Int).Mul(g, signCtx.GAcc), sk.D)
-	d.Mod(d, secp256k1.N)
+	d := g.Mul(signCtx.GAcc).Mul(sk.D)
 
 	// Apply the coefficient to our private key.
 	// 	d'' = a * d'
-	d.Mul(d, a)
+	d = d.Mul(a)
 
 	// r = k1 + b*k2
-	r := new(big.Int).Mul(k2.D, signCtx.B)
-	r.Add(r, k1.D)
+	r := k1.Add(k2.Mul(signCtx.B))
 
 	// ed = e * d''
-	ed := new(big.Int).Mul(signCtx.E, d)
+	ed := signCtx.E.Mul(d)
 
 	// s = (r + ed) % n
-	s := new(big.Int).Add(r, ed)
-	s.Mod(s, secp256k1.N)
-
-	ps, err := NewPartialSig(s)
-	if err != nil {
-		return nil, err
-	}
+	ps := NewPartialSigFromScalar(r.Add(ed))
 
 	err = ps.VerifyInternal(ctx, sn.GetPubNonce(), sk.PubKey)
 	if err != nil {
@@ -163,12 +168,22 @@ func (ps *PartialSig) VerifyInternal(ctx *SessionContext, pubNonce *PubNonce,
 		return err
 	}
 
+	// s*G =? Re + g*e*a*P is checked with a single multi-scalar
+	// multiplication as s*G + (-Re) + g*e*a*(-P) =? infinity, with the
+	// signs folded into the points.
+	//
 	// Re = R1 + b*R2
 	// If the final R has odd Y, then all parties need to negate their
-	// individual nonces to get the final Schnorr R to be even Y.
-	Re := pubNonce.R1.Add(pubNonce.R2.Mul(signCtx.B))
-	if !signCtx.R.HasEvenY() {
-		Re = Re.Mul(big.NewInt(-1))
+	// individual nonces to get the final Schnorr R to be even Y. So the
+	// nonces are negated here only if the final R has an even Y.
+	group := pk.Group()
+	if !ps.S.InGroup(group) {
+		return schnorr.ErrScalarNotInGroup
+	}
+
+	r1, r2 := pubNonce.R1.Point, pubNonce.R2.Point
+	if signCtx.R.HasEvenY() {
+		r1, r2 = r1.Neg(), r2.Neg()
 	}
 
 	// Get the coefficient that the pub key should have been tweaked by.
@@ -177,24 +192,26 @@ func (ps *PartialSig) VerifyInternal(ctx *SessionContext, pubNonce *PubNonce,
 		return err
 	}
 
-	g := big.NewInt(1)
-	if !signCtx.Q.HasEvenY() {
-		g.Mod(big.NewInt(-1), secp256k1.N)
+	// g is 1 or -1 depending on the parity of Q, and is multiplied by
+	// gacc. In the same way as for the nonces, its sign is folded into P,
+	// which is negated only if Q has an even Y.
+	p := pk.Point
+	if signCtx.Q.HasEvenY() {
+		p = p.Neg()
 	}
 
-	// g = (g * gacc) %n
-	g.Mul(g, signCtx.GAcc)
-	g.Mod(g, secp256k1.N)
-
-	S := secp256k1.G.Mul(ps.S)
-
-	// P' = g * e * a * P
-	pdash := pk.Mul(g).Mul(signCtx.E).Mul(a)
-
-	// Re + P'
-	rightSide := pdash.Add(Re)
+	sum, err := group.MultiScalarMul(
+		[]*ellipticcurve.Point{group.Generator(), r1, r2, p},
+		[]*schnorr.Scalar{
+			ps.S, schnorr.NewScalar(group, 1), signCtx.B,
+			signCtx.GAcc.Mul(signCtx.E).Mul(a),
+		},
+	)
+	if err != nil {
+		return err
+	}
 
-	if !S.Equal(rightSide.Point) {
+	if !sum.IsInfinity {
 		return fmt.Errorf("fail")
 	}
 
@@ -206,36 +223,33 @@ func (ps *PartialSig) VerifyInternal(ctx *SessionContext, pubNonce *PubNonce,
 func (ctx *SessionContext) PartialSigAgg(psigs []*PartialSig) (
 	*schnorr.Signature, error) {
 
+	signCtx, err := ctx.GetSigContext()
+	if err != nil {
+		return nil, err
+	}
+
 	// Add all the sigs together.
 	// s = s1+s2+....su
-	s := big.NewInt(0)
+	group := signCtx.Q.Group()
+	s := schnorr.NewScalar(group, 0)
 	for _, psig := range psigs {
-		s.Add(s, psig.S)
-		s.Mod(s, secp256k1.N)
-	}
+		if !psig.S.InGroup(group) {
+			return nil, schnorr.ErrScalarNotInGroup
+		}
 
-	signCtx, err := ctx.GetSigContext()
-	if err != nil {
-		return nil, err
+		s = s.Add(psig.S)
 	}
 
 	// Finally, we add the tweak to the signature.
 	// 	s = (sagg + e*g*tacc) %n
-	g := big.NewInt(1)
+	g := schnorr.NewScalar(group, 1)
 	if !signCtx.Q.HasEvenY() {
-		g.Mod(big.NewInt(-1), secp256k1.N)
+		g = g.Negate()
 	}
 
-	c := new(big.Int).Mul(signCtx.E, g)
-	c.Mul(c, signCtx.TAcc)
-
-	s.Add(s, c)
-	s.Mod(s, secp256k1.N)
+	s = s.Add(signCtx.E.Mul(g).Mul(signCtx.TAcc))
 
-	sig, err := schnorr.NewSignature(signCtx.R, s)
-	if err != nil {
-		return nil, err
-	}
+	sig := schnorr.NewSignatureFromScalar(signCtx.R, s)
 
 	err = sig.Verify(signCtx.Q, ctx.Msg)
 	if err != nil {
@@ -4,7 +4,11 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/ellemouton/schnorr"
+	"github.com/ellemouton/schnorr/ellipticcurve"
+	"github.com/ellemouton/schnorr/finitefield"
+	"github.com/ellemouton/schnorr/p256"
 	"github.com/stretchr/testify/require"
+	"math/big"
 	"testing"
 )
 
@@ -407,3 +411,148 @@ func TestPartialSigAgg(t *testing.T) {
 		})
 	}
 }
+
+// TestSignInGroups runs a full MuSig2 session with an x-only tweak over P-256
+// and a toy curve and checks that the aggregated signature verifies.
+func TestSignInGroups(t *testing.T) {
+	f, err := finitefield.NewField(big.NewInt(10099))
+	require.NoError(t, err)
+
+	// y^2 = x^3 + 3 over F_10099 has 9967 points, a prime, so (1, 2)
+	// generates all of them.
+	toyCurve, err := ellipticcurve.NewCurve(f.FromInt(0), f.FromInt(3))
+	require.NoError(t, err)
+
+	toyCurve, err = toyCurve.WithGenerator(
+		f.FromInt(1), f.FromInt(2), big.NewInt(9967), big.NewInt(1),
+	)
+	require.NoError(t, err)
+
+	for name, c := range map[string]*ellipticcurve.Curve{
+		"p256": p256.Curve,
+		"toy":  toyCurve,
+	} {
+		c := c
+		t.Run(name, func(t *testing.T) {
+			g, err := schnorr.NewGroup(c)
+			require.NoError(t, err)
+
+			msg := bytes.Repeat([]byte{0x42}, 32)
+
+			var (
+				sks    []*schnorr.PrivateKey
+				pks    []*schnorr.PublicKey
+				nonces []*Nonce
+				pns    []*PubNonce
+			)
+			for i := uint64(1); i <= 3; i++ {
+				sk, err := schnorr.PrivateKeyFromScalarInGroup(
+					g, schnorr.NewScalar(g, 100*i+1),
+				)
+				require.NoError(t, err)
+
+				nonce, err := NonceGen(
+					sk.PubKey, WithOptionSecretKey(sk),
+					WithRandBytes([32]byte{byte(i)}),
+				)
+				require.NoError(t, err)
+
+				// The nonces survive a round trip through
+				// their encodings in the Group.
+				sn, err := ParseSecNonceInGroup(
+					g, nonce.SecNonce.Bytes(),
+				)
+				require.NoError(t, err)
+				require.Equal(
+					t, nonce.SecNonce.Bytes(), sn.Bytes(),
+				)
+
+				pn, err := ParsePubNonceInGroup(
+					g, nonce.PubNonce.Bytes(),
+				)
+				require.NoError(t, err)
+				require.Equal(
+					t, nonce.PubNonce.Bytes(), pn.Bytes(),
+				)
+
+				sks = append(sks, sk)
+				pks = append(pks, sk.PubKey)
+				nonces = append(nonces, nonce)
+				pns = append(pns, nonce.PubNonce)
+			}
+
+			tweak, err := NewTweakInGroup(
+				g, schnorr.NewScalar(g, 5).Bytes(), true,
+			)
+			require.NoError(t, err)
+
+			tweaks := []*Tweak{tweak}
+			ctx := NewSessionContext(
+				NonceAgg(pns), pks, msg, tweaks,
+			)
+
+			psigs := make([]*PartialSig, len(sks))
+			for i, sk := range sks {
+				psigs[i], err = Sign(
+					ctx, nonces[i].SecNonce, sk,
+				)
+				require.NoError(t, err)
+
+				err = psigs[i].Verify(pns, pks, tweaks, msg, i)
+				require.NoError(t, err)
+
+				ps, err := ParsePartialSigInGroup(
+					g, psigs[i].Bytes(),
+				)
+				require.NoError(t, err)
+				require.True(t, ps.S.Equal(psigs[i].S))
+			}
+
+			sig, err := ctx.PartialSigAgg(psigs)
+			require.NoError(t, err)
+
+			signCtx, err := ctx.GetSigContext()
+			require.NoError(t, err)
+			require.NoError(t, sig.Verify(signCtx.Q, msg))
+
+			// Scalars of secp256k1 are rejected with an error.
+			other := NewPartialSigFromScalar(
+				schnorr.NewScalar(schnorr.Secp256k1, 1),
+			)
+			_, err = ctx.PartialSigAgg(append(psigs[1:], other))
+			require.ErrorIs(t, err, schnorr.ErrScalarNotInGroup)
+
+			err = other.VerifyInternal(ctx, pns[0], pks[0])
+			require.ErrorIs(t, err, schnorr.ErrScalarNotInGroup)
+
+			secpTweak, err := NewTweak(
+				bytes.Repeat([]byte{0x01}, 32), false,
+			)
+			require.NoError(t, err)
+
+			ctx.Tweaks = []*Tweak{secpTweak}
+			_, err = ctx.GetSigContext()
+			require.ErrorIs(t, err, schnorr.ErrScalarNotInGroup)
+		})
+	}
+}
+
+// TestNewPartialSig checks that the big.Int based NewPartialSig agrees with
+// ParsePartialSig and rejects values that are not less than N.
+func TestNewPartialSig(t *testing.T) {
+	b := bytes.Repeat([]byte{0x42}, PartialSigLen)
+
+	ps, err := NewPartialSig(new(big.Int).SetBytes(b))
+	require.NoError(t, err)
+	require.Equal(t, b, ps.Bytes())
+
+	N := schnorr.Secp256k1.ScalarField().Modulus()
+	_, err = NewPartialSig(N)
+	require.Error(t, err)
+
+	_, err = NewPartialSig(new(big.Int).Lsh(N, 8))
+	require.Error(t, err)
+
+	_, err = NewPartialSig(big.NewInt(-1))
+	require.Error(t, err)
+}
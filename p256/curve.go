@@ -0,0 +1,31 @@
+package p256
+
+import (
+	"github.com/ellemouton/schnorr/ellipticcurve"
+)
+
+const b = "5AC635D8AA3A93E7B3EBBD55769886BC651D06B0CC53B0F63BCE3C3E27D2604B"
+
+var (
+	// Curve is the NIST P-256 curve y^2 = x^3 - 3x + b, also known as
+	// secp256r1 and prime256v1.
+	Curve *ellipticcurve.Curve
+
+	A *FieldElement
+	B *FieldElement
+)
+
+func init() {
+	fieldInit()
+
+	A = &FieldElement{BaseField.FromInt(-3)}
+	B = &FieldElement{BaseField.FromBigInt(hexInt(b))}
+
+	var err error
+	Curve, err = ellipticcurve.NewCurve(A.Element, B.Element)
+	if err != nil {
+		panic("error initializing curve: " + err.Error())
+	}
+
+	pointInit()
+}
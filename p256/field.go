@@ -0,0 +1,132 @@
+package p256
+
+import (
+	"math/big"
+
+	"github.com/ellemouton/schnorr/finitefield"
+)
+
+var (
+	// P is the prime of the P-256 finite field, 2^256 - 2^224 + 2^192 +
+	// 2^96 - 1.
+	P *big.Int
+
+	// BaseField is the finite field of order P that the coordinates of
+	// the points on the curve are defined over.
+	BaseField *finitefield.Field
+)
+
+const p = "FFFFFFFF00000001000000000000000000000000FFFFFFFFFFFFFFFFFFFFFFFF"
+
+// FieldElement is a P-256 Element.
+//
+// Unlike the secp256k1 package, there is no arithmetic specialised to the
+// P-256 prime. The methods of FieldElement use the generic finitefield.Element
+// arithmetic, which makes the package a plain instantiation of the generic
+// code.
+type FieldElement struct {
+	*finitefield.Element
+}
+
+// NewFieldElement constructs a new FieldElement.
+func NewFieldElement(n *big.Int) (*FieldElement, error) {
+	e, err := finitefield.NewElement(n, P)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FieldElement{e}, nil
+}
+
+// Equal returns true if the passed Element is equivalent to this Element.
+func (e *FieldElement) Equal(o *FieldElement) bool {
+	return e.Element.Equal(o.Element)
+}
+
+// Add adds two Elements in the same finite field together.
+func (e *FieldElement) Add(o *FieldElement) (*FieldElement, error) {
+	res, err := e.Element.Add(o.Element)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FieldElement{res}, nil
+}
+
+// Sub subtracts the given Element from this Element.
+func (e *FieldElement) Sub(o *FieldElement) (*FieldElement, error) {
+	res, err := e.Element.Sub(o.Element)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FieldElement{res}, nil
+}
+
+// Mul multiplies the two Elements together.
+func (e *FieldElement) Mul(o *FieldElement) (*FieldElement, error) {
+	res, err := e.Element.Mul(o.Element)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FieldElement{res}, nil
+}
+
+// Pow defines exponentiation on the Element.
+func (e *FieldElement) Pow(exp *big.Int) *FieldElement {
+	return &FieldElement{e.Element.Pow(exp)}
+}
+
+// Div divides this FieldElement by the given FieldElement and returns the
+// resulting FieldElement.
+func (e *FieldElement) Div(o *FieldElement) (*FieldElement, error) {
+	res, err := e.Element.Div(o.Element)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FieldElement{res}, nil
+}
+
+// Sqrt returns a square root of the FieldElement. The other square root is its
+// negation. finitefield.ErrNoSquareRoot is returned if the FieldElement is not
+// a quadratic residue.
+func (e *FieldElement) Sqrt() (*FieldElement, error) {
+	res, err := e.Element.Sqrt()
+	if err != nil {
+		return nil, err
+	}
+
+	return &FieldElement{res}, nil
+}
+
+// Negate returns the additive inverse of the FieldElement.
+func (e *FieldElement) Negate() *FieldElement {
+	return &FieldElement{e.Element.Negate()}
+}
+
+// IsZero returns true if the FieldElement's number is zero.
+func (e *FieldElement) IsZero() bool {
+	return e.Element.IsZero()
+}
+
+func fieldInit() {
+	P = hexInt(p)
+
+	var err error
+	BaseField, err = finitefield.NewField(P)
+	if err != nil {
+		panic("could not init base field: " + err.Error())
+	}
+}
+
+// hexInt parses the given hex constant. It panics if the constant is invalid.
+func hexInt(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("invalid hex: " + s)
+	}
+
+	return n
+}
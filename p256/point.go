@@ -0,0 +1,141 @@
+package p256
+
+import (
+	"math/big"
+
+	"github.com/ellemouton/schnorr/ellipticcurve"
+	"github.com/ellemouton/schnorr/finitefield"
+)
+
+const (
+	n  = "FFFFFFFF00000000FFFFFFFFFFFFFFFFBCE6FAADA7179E84F3B9CAC2FC632551"
+	gx = "6B17D1F2E12C4247F8BCE6E563A440F277037D812DEB33A0F4A13945D898C296"
+	gy = "4FE342E2FE1A7F9B8EE7EB4A7C0F9E162BCE33576B315ECECBB6406837BF51F5"
+)
+
+var (
+	// G is the generator point of the curve. It wraps Curve.G(), and the
+	// order of the group that it generates is Curve.N().
+	G *Point
+
+	// ScalarField is the finite field of order Curve.N().
+	ScalarField *finitefield.Field
+)
+
+// Point is a point on the P-256 curve.
+type Point struct {
+	*ellipticcurve.Point
+}
+
+// NewPoint constructs a new Point.
+func NewPoint(x, y *FieldElement) (*Point, error) {
+	p, err := ellipticcurve.NewPoint(x.Element, y.Element, Curve)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Point{p}, nil
+}
+
+// ParsePoint decodes a Point from any of its SEC1 encodings. See
+// ellipticcurve.ParsePoint.
+func ParsePoint(b []byte) (*Point, error) {
+	p, err := ellipticcurve.ParsePoint(b, Curve)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Point{p}, nil
+}
+
+// NewInfinityPoint constructs a new Point at infinity.
+func NewInfinityPoint() *Point {
+	p := ellipticcurve.NewInfinityPoint(Curve)
+
+	return &Point{p}
+}
+
+// Mul does scalar multiplication on the point. The scalar is reduced modulo N
+// first.
+//
+// NOTE: the time taken depends on the value of c so this must only be used
+// for public scalars.
+func (p *Point) Mul(c *big.Int) *Point {
+	return &Point{p.Point.ScalarMult(c)}
+}
+
+// Copy returns a copy of the Point.
+func (p *Point) Copy() *Point {
+	return &Point{
+		Point: p.Point.Copy(),
+	}
+}
+
+// Equal returns true if Points are the same coordinate on the same curve.
+func (p *Point) Equal(o *Point) bool {
+	return p.Point.Equal(o.Point)
+}
+
+// Add adds the two points together.
+func (p *Point) Add(o *Point) *Point {
+	res, err := p.Point.Add(o.Point)
+	if err != nil {
+		// Add will only ever error if the points being added are not
+		// on the same curve. Point guarantees that they are.
+		panic(err)
+	}
+
+	return &Point{res}
+}
+
+// Sub subtracts the given point from this point.
+func (p *Point) Sub(o *Point) *Point {
+	res, err := p.Point.Sub(o.Point)
+	if err != nil {
+		// Sub will only ever error if the points are not on the same
+		// curve. Point guarantees that they are.
+		panic(err)
+	}
+
+	return &Point{res}
+}
+
+// Neg returns the inverse of the point, -p.
+func (p *Point) Neg() *Point {
+	return &Point{p.Point.Neg()}
+}
+
+// Double returns p + p.
+func (p *Point) Double() *Point {
+	return &Point{p.Point.Double()}
+}
+
+func pointInit() {
+	order := hexInt(n)
+
+	var err error
+	ScalarField, err = finitefield.NewField(order)
+	if err != nil {
+		panic("could not init scalar field: " + err.Error())
+	}
+
+	gX, err := NewFieldElement(hexInt(gx))
+	if err != nil {
+		panic("could not make FieldElement for Gx: " + err.Error())
+	}
+
+	gY, err := NewFieldElement(hexInt(gy))
+	if err != nil {
+		panic("could not make FieldElement for Gy: " + err.Error())
+	}
+
+	// P-256 has prime order so the cofactor is one.
+	Curve, err = Curve.WithGenerator(
+		gX.Element, gY.Element, order, big.NewInt(1),
+	)
+	if err != nil {
+		panic("could not init generator point: " + err.Error())
+	}
+
+	G = &Point{Curve.G()}
+}
@@ -0,0 +1,140 @@
+package p256
+
+import (
+	"crypto/ecdh"
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/ellemouton/schnorr/ellipticcurve"
+	"github.com/stretchr/testify/require"
+)
+
+// The tests in this file cross-check the generic ellipticcurve code, as
+// instantiated for P-256, against the implementations in the standard library.
+// crypto/elliptic is deprecated for direct use of its arithmetic, but it is
+// still the simplest way to get at affine coordinates.
+
+// stdCurve is the standard library's P-256.
+var stdCurve = elliptic.P256()
+
+// TestBasics checks the curve constants against those of crypto/elliptic.
+func TestBasics(t *testing.T) {
+	params := stdCurve.Params()
+
+	require.Equal(t, params.P, P)
+	require.Equal(t, params.N, Curve.N())
+	require.Equal(t, params.B, B.Num)
+	require.Equal(t, params.Gx, G.X().Num)
+	require.Equal(t, params.Gy, G.Y().Num)
+
+	require.True(t, Curve.Contains(G.X(), G.Y()))
+	require.True(t, G.Mul(Curve.N()).IsInfinity)
+}
+
+// TestScalarMult checks multiplication by random scalars against
+// crypto/elliptic.
+func TestScalarMult(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		k := randScalar(t)
+
+		wantX, wantY := stdCurve.ScalarBaseMult(k.Bytes())
+		requireCoords(t, G.Mul(k), wantX, wantY)
+
+		p := G.Mul(randScalar(t))
+		wantX, wantY = stdCurve.ScalarMult(p.X().Num, p.Y().Num, k.Bytes())
+		requireCoords(t, p.Mul(k), wantX, wantY)
+	}
+}
+
+// TestAdd checks addition, including doubling and adding a point to its
+// negation, against crypto/elliptic.
+func TestAdd(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		p := G.Mul(randScalar(t))
+		q := G.Mul(randScalar(t))
+
+		wantX, wantY := stdCurve.Add(p.X().Num, p.Y().Num, q.X().Num,
+			q.Y().Num)
+		requireCoords(t, p.Add(q), wantX, wantY)
+
+		wantX, wantY = stdCurve.Double(p.X().Num, p.Y().Num)
+		requireCoords(t, p.Add(p), wantX, wantY)
+
+		negP := p.Neg()
+		require.True(t, p.Add(negP).IsInfinity)
+		require.True(t, p.Add(NewInfinityPoint()).Equal(p))
+	}
+}
+
+// TestEncoding checks the SEC1 encodings against crypto/elliptic.
+func TestEncoding(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		p := G.Mul(randScalar(t))
+		x, y := p.X().Num, p.Y().Num
+
+		compressed := p.Encode(ellipticcurve.FormatCompressed)
+		require.Equal(t, elliptic.MarshalCompressed(stdCurve, x, y),
+			compressed)
+
+		uncompressed := p.Encode(ellipticcurve.FormatUncompressed)
+		require.Equal(t, elliptic.Marshal(stdCurve, x, y), uncompressed)
+
+		for _, b := range [][]byte{compressed, uncompressed} {
+			dec, err := ParsePoint(b)
+			require.NoError(t, err)
+			require.True(t, p.Equal(dec))
+		}
+
+		// The standard library decompresses the points that we
+		// compress.
+		gotX, gotY := elliptic.UnmarshalCompressed(stdCurve, compressed)
+		require.Equal(t, x, gotX)
+		require.Equal(t, y, gotY)
+	}
+}
+
+// TestECDH checks public keys and shared secrets against crypto/ecdh.
+func TestECDH(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		a, err := ecdh.P256().GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		b, err := ecdh.P256().GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		// The public key is the uncompressed encoding of a*G.
+		kA := new(big.Int).SetBytes(a.Bytes())
+		pubA := G.Mul(kA)
+		require.Equal(t, a.PublicKey().Bytes(),
+			pubA.Encode(ellipticcurve.FormatUncompressed))
+
+		// The shared secret is the X coordinate of a*B.
+		pubB, err := ParsePoint(b.PublicKey().Bytes())
+		require.NoError(t, err)
+
+		want, err := a.ECDH(b.PublicKey())
+		require.NoError(t, err)
+		require.Equal(t, want, pubB.Mul(kA).X().Bytes())
+	}
+}
+
+// randScalar returns a random scalar in [1, N).
+func randScalar(t *testing.T) *big.Int {
+	t.Helper()
+
+	k, err := rand.Int(rand.Reader, new(big.Int).Sub(Curve.N(), big.NewInt(1)))
+	require.NoError(t, err)
+
+	return k.Add(k, big.NewInt(1))
+}
+
+// requireCoords checks that p has the given affine coordinates.
+func requireCoords(t *testing.T, p *Point, x, y *big.Int) {
+	t.Helper()
+
+	require.False(t, p.IsInfinity)
+	require.Zero(t, x.Cmp(p.X().Num))
+	require.Zero(t, y.Cmp(p.Y().Num))
+}
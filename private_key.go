@@ -18,32 +18,49 @@ const (
 	Bip340ChallengeTag = "BIP0340/challenge"
 )
 
-// PrivateKey defines a private key required to create a schnorr signature.
+// PrivateKey defines a private key required to create a schnorr signature. Its
+// Group is the Group of its PubKey.
 type PrivateKey struct {
-	D      *big.Int
+	D      *Scalar
 	PubKey *PublicKey
 }
 
-// NewPrivateKey generates a new random PrivateKey.
+// NewPrivateKey generates a new random secp256k1 PrivateKey.
 func NewPrivateKey() (*PrivateKey, error) {
-	d, err := randFieldElement(rand.Reader)
+	return NewPrivateKeyInGroup(Secp256k1)
+}
+
+// NewPrivateKeyInGroup generates a new random PrivateKey in the given Group.
+func NewPrivateKeyInGroup(g Group) (*PrivateKey, error) {
+	d, err := randFieldElement(rand.Reader, g.ScalarField().Modulus())
 	if err != nil {
 		return nil, err
 	}
 
-	return PrivateKeyFromInt(d)
+	b := d.FillBytes(make([]byte, ScalarLen(g)))
+
+	return PrivateKeyFromScalarInGroup(g, ScalarFromBytesReduce(g, b))
 }
 
-// ParsePrivKeyBytes constructs a new PrivateKey from the given byte slice.
+// ParsePrivKeyBytes constructs a new secp256k1 PrivateKey from the given byte
+// slice.
 func ParsePrivKeyBytes(sk []byte) (*PrivateKey, error) {
 	if len(sk) != PrivKeyBytesLen {
 		return nil, fmt.Errorf("incorrect number of byte")
 	}
 
-	var d big.Int
-	d.SetBytes(sk)
+	return ParsePrivKeyBytesInGroup(Secp256k1, sk)
+}
 
-	return PrivateKeyFromInt(&d)
+// ParsePrivKeyBytesInGroup constructs a new PrivateKey of the given Group from
+// the given byte slice, which must hold the encoding of a Scalar of the Group.
+func ParsePrivKeyBytesInGroup(g Group, sk []byte) (*PrivateKey, error) {
+	d, err := ScalarFromBytes(g, sk)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	return PrivateKeyFromScalarInGroup(g, d)
 }
 
 // ParsePrivKeyHexString constructs a new PrivateKey from the given hex string.
@@ -56,21 +73,53 @@ func ParsePrivKeyHexString(s string) (*PrivateKey, error) {
 	return ParsePrivKeyBytes(b)
 }
 
-// PrivateKeyFromInt creates a new PrivateKey from the given secret key.
+// PrivateKeyFromInt creates a new secp256k1 PrivateKey from the given secret
+// key which must be in the range [1, N).
 func PrivateKeyFromInt(d *big.Int) (*PrivateKey, error) {
-	if d.Sign() <= 0 || d.Cmp(secp256k1.N) > 0 {
+	if d.Sign() <= 0 || d.Cmp(secp256k1.Curve.N()) >= 0 {
+		return nil, fmt.Errorf("invalid private key generated")
+	}
+
+	var b [PrivKeyBytesLen]byte
+	d.FillBytes(b[:])
+
+	return PrivateKeyFromScalarInGroup(Secp256k1, ScalarFromBytesReduce(
+		Secp256k1, b[:],
+	))
+}
+
+// PrivateKeyFromScalar creates a new secp256k1 PrivateKey from the given secret
+// key which must not be zero.
+func PrivateKeyFromScalar(d *secp256k1.Scalar) (*PrivateKey, error) {
+	b := d.Bytes()
+
+	return PrivateKeyFromScalarInGroup(
+		Secp256k1, ScalarFromBytesReduce(Secp256k1, b[:]),
+	)
+}
+
+// PrivateKeyFromScalarInGroup creates a new PrivateKey in the given Group from
+// the given secret key which must be a non-zero Scalar of the Group.
+func PrivateKeyFromScalarInGroup(g Group, d *Scalar) (*PrivateKey, error) {
+	if !d.InGroup(g) {
+		return nil, ErrScalarNotInGroup
+	}
+
+	if d.IsZero() {
 		return nil, fmt.Errorf("invalid private key generated")
 	}
 
 	return &PrivateKey{
 		D:      d,
-		PubKey: NewPublicKey(secp256k1.G.Mul(d)),
+		PubKey: NewPublicKeyInGroup(g, g.ScalarBaseMult(d)),
 	}, nil
 }
 
-// Bytes returns the 32 byte representation of the private key.
+// Bytes returns the 32 byte representation of the private key. For Groups with
+// an order shorter than 256 bits, the encoding of the Scalar is padded with
+// leading zeros.
 func (p *PrivateKey) Bytes() [PrivKeyBytesLen]byte {
-	return skBytes(p.D)
+	return pad32(p.D.Bytes())
 }
 
 // Sign uses the PrivateKey to sign the given message and produce a valid
@@ -80,68 +129,61 @@ func (p *PrivateKey) Sign(msg, aux []byte) (*Signature, error) {
 		return nil, fmt.Errorf("msg and aux must have len 32")
 	}
 
-	// Make a copy of the secret key.
-	// 	Let D' = int(sk)
-	var d big.Int
-	d.Add(p.D, big.NewInt(0))
+	g := p.PubKey.Group()
+	if !p.D.InGroup(g) {
+		return nil, ErrScalarNotInGroup
+	}
 
+	// All the arithmetic on the secret key and the nonce below is done with
+	// constant time Scalars so that the time taken to sign does not leak
+	// either of them.
+	//
 	// Negate the secret key if the public key has an odd Y.
 	// 	Let D = D' if has_even_y(P), otherwise let D = n - D'
-	if !p.PubKey.HasEvenY() {
-		d.Sub(secp256k1.N, &d)
-	}
+	d := p.D.CondNegate(oddY(p.PubKey))
 
 	// Let t be the byte-wise Xor of bytes(D) and hashBIP0340/aux(a)
-	t := Xor(skBytes(&d), TaggedHash(Bip340AuxTag, aux[:]))
+	t := Xor(pad32(d.Bytes()), TaggedHash(Bip340AuxTag, aux[:]))
 
 	// Let rand = hashBIP0340/nonce(t || bytes(P) || m)
 	pBytes := p.PubKey.XOnlyBytes()
 	rand := TaggedHash(Bip340NonceTag, t[:], pBytes[:], msg[:])
 
 	// Let k' = int(rand) mod n
-	k := IntFromBytes(rand)
+	k := ScalarFromBytesReduce(g, rand[:])
 
 	// Fail if k' = 0.
-	if k.Sign() == 0 {
+	if k.IsZero() {
 		return nil, fmt.Errorf("failed to sign with zero value k")
 	}
 
 	// Let R = k'⋅G.
-	R := NewPublicKey(secp256k1.G.Mul(k))
+	R := NewPublicKeyInGroup(g, g.ScalarBaseMult(k))
 
 	// Let k = k' if has_even_y(R), otherwise let k = n - k'.
-	if !R.HasEvenY() {
-		k.Sub(secp256k1.N, k)
-	}
+	k = k.CondNegate(oddY(R))
 
 	// Let e = int(hashBIP0340/challenge(bytes(R) || bytes(P) || m)) mod n
-	e := IntFromBytes(
-		TaggedHash(
-			Bip340ChallengeTag, R.XOnlyBytes()[:], pBytes[:], msg,
-		),
+	eHash := TaggedHash(
+		Bip340ChallengeTag, R.XOnlyBytes()[:], pBytes[:], msg,
 	)
+	e := ScalarFromBytesReduce(g, eHash[:])
 
-	s := k.Mod(k.Add(k, e.Mul(e, &d)), secp256k1.N)
+	// Let s = (k + e⋅D) mod n.
+	sig := NewSignatureFromScalar(R, k.Add(e.Mul(d)))
 
-	sig, err := NewSignature(R, s)
-	if err != nil {
-		return nil, err
-	}
-
-	if err = sig.Verify(p.PubKey, msg); err != nil {
+	if err := sig.Verify(p.PubKey, msg); err != nil {
 		return nil, fmt.Errorf("sig verification failed: %v", err)
 	}
 
 	return sig, nil
 }
 
-// randFieldElement returns a random element of the order of the secp256k1
-// curve.
+// randFieldElement returns a random non-zero integer less than N.
 //
 // NOTE: this is copied from /usr/local/go/src/crypto/ecdsa/ecdsa_legacy.go.
-func randFieldElement(rand io.Reader) (*big.Int, error) {
+func randFieldElement(rand io.Reader, N *big.Int) (*big.Int, error) {
 	var (
-		N   = secp256k1.N
 		k   big.Int
 		err error
 	)
@@ -162,11 +204,22 @@ func randFieldElement(rand io.Reader) (*big.Int, error) {
 	return &k, err
 }
 
-func skBytes(d *big.Int) [32]byte {
-	var b [32]byte
-	d.FillBytes(b[:])
+// oddY returns 1 if the public key has an odd Y coordinate and 0 otherwise. The
+// result can be passed to the constant time Scalar methods.
+func oddY(pk *PublicKey) int {
+	if pk.HasEvenY() {
+		return 0
+	}
+
+	return 1
+}
+
+// pad32 returns b padded with leading zeros to 32 bytes.
+func pad32(b []byte) [32]byte {
+	var res [32]byte
+	copy(res[32-len(b):], b)
 
-	return b
+	return res
 }
 
 func Xor(a, b [32]byte) [32]byte {
@@ -178,10 +231,11 @@ func Xor(a, b [32]byte) [32]byte {
 	return c
 }
 
+// IntFromBytes returns the big-endian value of b reduced modulo the order of
+// secp256k1.
+//
+// Deprecated: use ScalarFromBytesReduce, which does not hold the value in a
+// variable time big.Int.
 func IntFromBytes(b [32]byte) *big.Int {
-	var res big.Int
-	res.SetBytes(b[:])
-	res.Mod(&res, secp256k1.N)
-
-	return &res
+	return ScalarFromBytesReduce(Secp256k1, b[:]).BigInt()
 }
@@ -3,6 +3,7 @@ package schnorr
 import (
 	"encoding/hex"
 	"fmt"
+	"github.com/ellemouton/schnorr/ellipticcurve"
 	"github.com/ellemouton/schnorr/secp256k1"
 	"math/big"
 )
@@ -12,20 +13,37 @@ const (
 	PlainPubKeyBytesLen = 33
 )
 
-// PublicKey is a public key.
+// PublicKey is a public key. It is a point of a Group, which is Secp256k1 if it
+// was not given one.
 type PublicKey struct {
-	*secp256k1.Point
+	*ellipticcurve.Point
+
+	group Group
 }
 
-// NewPublicKey constructs a new Public key from the given secp256k1 point.
+// NewPublicKey constructs a new secp256k1 PublicKey from the given point.
 func NewPublicKey(p *secp256k1.Point) *PublicKey {
-	return &PublicKey{p}
+	return NewPublicKeyInGroup(Secp256k1, p.Point)
+}
+
+// NewPublicKeyInGroup constructs a new PublicKey from the given point of the
+// Group.
+func NewPublicKeyInGroup(g Group, p *ellipticcurve.Point) *PublicKey {
+	return &PublicKey{Point: p, group: g}
 }
 
-// NewInfinityPubKey constructs a new PublicKey at infinity. This is effectively
-// a zero value PublicKey.
+// NewInfinityPubKey constructs a new secp256k1 PublicKey at infinity. This is
+// effectively a zero value PublicKey.
 func NewInfinityPubKey() *PublicKey {
-	return &PublicKey{secp256k1.NewInfinityPoint()}
+	return NewInfinityPubKeyInGroup(Secp256k1)
+}
+
+// NewInfinityPubKeyInGroup constructs a new PublicKey at infinity in the given
+// Group.
+func NewInfinityPubKeyInGroup(g Group) *PublicKey {
+	p := ellipticcurve.NewInfinityPoint(g.Generator().Curve)
+
+	return NewPublicKeyInGroup(g, p)
 }
 
 // ParseXOnlyPubKeyHexString constructs a new PublicKey from the passed hex
@@ -39,17 +57,39 @@ func ParseXOnlyPubKeyHexString(s string) (*PublicKey, error) {
 	return ParseXOnlyPubKey(b)
 }
 
-// ParseXOnlyPubKey constructs a new PublicKey from the passed bytes slice.
+// ParseXOnlyPubKey constructs a new secp256k1 PublicKey from the passed bytes
+// slice. The point with the given X coordinate and an even Y coordinate is
+// returned.
 func ParseXOnlyPubKey(b []byte) (*PublicKey, error) {
 	if len(b) != XOnlyPubKeyBytesLen {
 		return nil, fmt.Errorf("incorrect number of bytes for an " +
 			"x-only pub key")
 	}
 
-	var xInt big.Int
-	xInt.SetBytes(b)
+	return ParseXOnlyPubKeyInGroup(Secp256k1, b)
+}
+
+// ParseXOnlyPubKeyInGroup constructs a new PublicKey of the given Group from
+// the passed byte slice, which must hold the fixed-width encoding of an X
+// coordinate. The point with that X coordinate and an even Y coordinate is
+// returned.
+func ParseXOnlyPubKeyInGroup(g Group, b []byte) (*PublicKey, error) {
+	if len(b) != g.BaseField().ByteLen() {
+		return nil, fmt.Errorf("incorrect number of bytes for an " +
+			"x-only pub key")
+	}
+
+	x, err := g.BaseField().FromBytes(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid x-only pub key: %w", err)
+	}
+
+	p, err := g.LiftX(x)
+	if err != nil {
+		return nil, fmt.Errorf("invalid x-only pub key: %w", err)
+	}
 
-	return LiftX(&xInt)
+	return NewPublicKeyInGroup(g, p), nil
 }
 
 // ParsePlainPubKeyHexString constructs a new PublicKey from the passed hex
@@ -63,55 +103,69 @@ func ParsePlainPubKeyHexString(s string) (*PublicKey, error) {
 	return ParsePlainPubKey(b)
 }
 
-// ParsePlainPubKey constructs a new PublicKey from the passed byte slice.
+// ParsePlainPubKey constructs a new secp256k1 PublicKey from the passed byte
+// slice, which must hold the SEC1 compressed encoding of a point.
 func ParsePlainPubKey(b []byte) (*PublicKey, error) {
 	if len(b) != PlainPubKeyBytesLen {
 		return nil, fmt.Errorf("incorrect number of bytes for a " +
 			"plain pub key")
 	}
 
+	return ParsePlainPubKeyInGroup(Secp256k1, b)
+}
+
+// ParsePlainPubKeyInGroup constructs a new PublicKey of the given Group from
+// the passed byte slice, which must hold the SEC1 compressed encoding of a
+// point.
+func ParsePlainPubKeyInGroup(g Group, b []byte) (*PublicKey, error) {
+	if len(b) != 1+g.BaseField().ByteLen() {
+		return nil, fmt.Errorf("incorrect number of bytes for a " +
+			"plain pub key")
+	}
+
 	if b[0] != 0x02 && b[0] != 0x03 {
 		return nil, fmt.Errorf("invalid pub key tag")
 	}
 
-	var xInt big.Int
-	xInt.SetBytes(b[1:])
+	x, err := g.BaseField().FromBytes(b[1:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid plain pub key: %w", err)
+	}
 
-	p, err := LiftX(&xInt)
+	// The point is lifted through the Group so that it is checked to be
+	// in the Group, and then negated if it has an odd Y.
+	p, err := g.LiftX(x)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid plain pub key: %w", err)
 	}
 
-	// Change the Y coordinate depending on the parity byte.
-	if b[0] == 0x02 {
-		return p, nil
+	if b[0] == 0x03 {
+		p = p.Neg()
 	}
 
-	p.Y.Num.Sub(secp256k1.P, p.Y.Num)
+	return NewPublicKeyInGroup(g, p), nil
+}
 
-	return p, nil
+// Group returns the Group that the PublicKey is a point of. A PublicKey that
+// was built without one, such as a zero-value PublicKey, is in Secp256k1.
+func (p *PublicKey) Group() Group {
+	if p.group == nil {
+		return Secp256k1
+	}
+
+	return p.group
 }
 
-// XOnlyBytes returns the 32 byte representation of the PublicKey.
+// XOnlyBytes returns the x-only representation of the PublicKey, which is 32
+// bytes long for secp256k1.
 func (p *PublicKey) XOnlyBytes() []byte {
-	var b [XOnlyPubKeyBytesLen]byte
-	p.X.Num.FillBytes(b[:])
-
-	return b[:]
+	return p.X().Bytes()
 }
 
-// PlainBytes returns the 33 byte compressed representation of the PublicKey.
+// PlainBytes returns the compressed representation of the PublicKey, which is
+// 33 bytes long for secp256k1.
 func (p *PublicKey) PlainBytes() []byte {
-	var b [PlainPubKeyBytesLen]byte
-	p.X.Num.FillBytes(b[1:])
-
-	if p.HasEvenY() {
-		b[0] = 0x02
-	} else {
-		b[0] = 0x03
-	}
-
-	return b[:]
+	return p.Encode(ellipticcurve.FormatCompressed)
 }
 
 // HasEvenY returns true if the public key'S Y coordinate is even.
@@ -120,12 +174,12 @@ func (p *PublicKey) HasEvenY() bool {
 		return true
 	}
 
-	return p.Y.Num.Bit(0) == 0
+	return p.Y().Num.Bit(0) == 0
 }
 
 // Copy returns a new copy of the PublicKey.
 func (p *PublicKey) Copy() *PublicKey {
-	return &PublicKey{p.Point.Copy()}
+	return NewPublicKeyInGroup(p.Group(), p.Point.Copy())
 }
 
 // Equal returns true if the two PublicKeys are the same.
@@ -134,61 +188,54 @@ func (p *PublicKey) Equal(o *PublicKey) bool {
 		return p.IsInfinity && o.IsInfinity
 	}
 
-	return p.X.Equal(o.X)
+	return p.X().Equal(o.X())
 }
 
 // Add adds the two PublicKey points and returns the result.
 func (p *PublicKey) Add(o *PublicKey) *PublicKey {
-	return &PublicKey{p.Point.Add(o.Point)}
-}
+	sum := new(ellipticcurve.Point).SetAdd(p.Point, o.Point)
 
-// Mul multiplies the Public key with the given constant and returns the result.
-func (p *PublicKey) Mul(c *big.Int) *PublicKey {
-	return &PublicKey{p.Point.Mul(c)}
+	return NewPublicKeyInGroup(p.Group(), sum)
 }
 
-// LiftX calculates the PublicKey associated with the given x coordinate that
-// has the even y coordinate.
-func LiftX(xInt *big.Int) (*PublicKey, error) {
-	x, err := secp256k1.NewFieldElement(xInt)
-	if err != nil {
-		return nil, err
-	}
+// Sub subtracts the given PublicKey point from this one and returns the
+// result.
+func (p *PublicKey) Sub(o *PublicKey) *PublicKey {
+	diff := new(ellipticcurve.Point).SetSub(p.Point, o.Point)
 
-	seven, err := secp256k1.NewFieldElement(big.NewInt(7))
-	if err != nil {
-		return nil, err
-	}
+	return NewPublicKeyInGroup(p.Group(), diff)
+}
 
-	c, err := x.Pow(big.NewInt(3)).Add(seven)
-	if err != nil {
-		return nil, err
-	}
+// Neg returns the inverse of the PublicKey point. Since it has the same X
+// coordinate, it has the same x-only encoding but the opposite parity.
+func (p *PublicKey) Neg() *PublicKey {
+	return NewPublicKeyInGroup(p.Group(), p.Point.Neg())
+}
 
-	var exp big.Int
-	exp.Add(secp256k1.P, big.NewInt(1))
-	exp.Div(&exp, big.NewInt(4))
+// Double adds the PublicKey point to itself and returns the result.
+func (p *PublicKey) Double() *PublicKey {
+	return NewPublicKeyInGroup(p.Group(), p.Point.Double())
+}
+
+// Mul multiplies the Public key with the given scalar and returns the result.
+func (p *PublicKey) Mul(c *Scalar) *PublicKey {
+	g := p.Group()
 
-	y := c.Pow(&exp)
+	return NewPublicKeyInGroup(g, g.ScalarMult(p.Point, c))
+}
 
-	y2, err := y.Mul(y)
+// LiftX calculates the secp256k1 PublicKey associated with the given x
+// coordinate that has the even y coordinate.
+func LiftX(xInt *big.Int) (*PublicKey, error) {
+	x, err := Secp256k1.BaseField().NewElement(xInt)
 	if err != nil {
 		return nil, err
 	}
 
-	if !y2.Equal(c) {
-		return nil, fmt.Errorf("could not lift x")
-	}
-
-	// Make sure that the point returned has an even Y value.
-	if y.Num.Bit(0) != 0 {
-		y.Num.Sub(secp256k1.P, y.Num)
-	}
-
-	point, err := secp256k1.NewPoint(x, y)
+	p, err := Secp256k1.LiftX(x)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid x-only pub key: %w", err)
 	}
 
-	return NewPublicKey(point), nil
+	return NewPublicKeyInGroup(Secp256k1, p), nil
 }
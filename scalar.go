@@ -0,0 +1,131 @@
+package schnorr
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ellemouton/schnorr/finitefield"
+)
+
+var (
+	// ErrScalarOutOfRange is returned when a Scalar is decoded from bytes
+	// that do not hold a value less than the order of the Group.
+	ErrScalarOutOfRange = errors.New("scalar out of range")
+
+	// ErrScalarNotInGroup is returned when a Scalar is used with a Group
+	// of a different order than the Group it was created for.
+	ErrScalarNotInGroup = errors.New("scalar is not a scalar of the group")
+)
+
+// Scalar is an integer modulo the order N of a Group. Private keys, nonces,
+// challenges and signature values are all Scalars.
+//
+// Like secp256k1.Scalar, a Scalar is backed by a finitefield.SecretElement so
+// all of its arithmetic runs in constant time and Scalars can hold secret
+// values. A Scalar is immutable: every method returns a new Scalar. The methods
+// that combine two Scalars panic if the Scalars belong to Groups of different
+// orders, so functions that take Scalars from their callers check them with
+// InGroup first and return ErrScalarNotInGroup instead.
+type Scalar struct {
+	e *finitefield.SecretElement
+}
+
+// NewScalar constructs a new Scalar of the Group from the given small integer.
+func NewScalar(g Group, v uint64) *Scalar {
+	var b [8]byte
+	new(big.Int).SetUint64(v).FillBytes(b[:])
+
+	return ScalarFromBytesReduce(g, b[:])
+}
+
+// ScalarFromBytes constructs a new Scalar of the Group from its fixed-width
+// big-endian encoding, which is ScalarLen bytes long. ErrScalarOutOfRange is
+// returned if the encoded value is not less than the order of the Group.
+func ScalarFromBytes(g Group, b []byte) (*Scalar, error) {
+	if len(b) != ScalarLen(g) {
+		return nil, fmt.Errorf("scalar must be %d bytes", ScalarLen(g))
+	}
+
+	// Reduce the value and check that the reduction did not change it.
+	s := ScalarFromBytesReduce(g, b)
+	if subtle.ConstantTimeCompare(s.e.Bytes(), b) != 1 {
+		return nil, ErrScalarOutOfRange
+	}
+
+	return s, nil
+}
+
+// ScalarFromBytesReduce constructs a new Scalar of the Group from the
+// big-endian value reduced modulo the order of the Group. This is the
+// int(b) mod n operation used to turn hash outputs into Scalars.
+func ScalarFromBytesReduce(g Group, b []byte) *Scalar {
+	return &Scalar{g.ScalarField().SecretFromBytes(b)}
+}
+
+// ScalarLen returns the length of the fixed-width encoding of the Scalars of
+// the Group.
+func ScalarLen(g Group) int {
+	return g.ScalarField().ByteLen()
+}
+
+// InGroup returns true if the Scalar is a Scalar of the given Group, that is if
+// the Group has the same order as the Group that the Scalar was created for.
+func (s *Scalar) InGroup(g Group) bool {
+	return g.ScalarField().ContainsSecret(s.e)
+}
+
+// Bytes returns the fixed-width big-endian encoding of the Scalar.
+func (s *Scalar) Bytes() []byte {
+	return s.e.Bytes()
+}
+
+// BigInt returns the value of the Scalar as a big.Int in the range [0, N).
+//
+// NOTE: big.Int values are not handled in constant time so this must only be
+// used for public values.
+func (s *Scalar) BigInt() *big.Int {
+	return s.e.Element().Num
+}
+
+// Add returns s + o mod N.
+func (s *Scalar) Add(o *Scalar) *Scalar {
+	res, err := s.e.Add(o.e)
+	if err != nil {
+		panic(err)
+	}
+
+	return &Scalar{res}
+}
+
+// Mul returns s * o mod N.
+func (s *Scalar) Mul(o *Scalar) *Scalar {
+	res, err := s.e.Mul(o.e)
+	if err != nil {
+		panic(err)
+	}
+
+	return &Scalar{res}
+}
+
+// Negate returns -s mod N.
+func (s *Scalar) Negate() *Scalar {
+	return &Scalar{s.e.Negate()}
+}
+
+// CondNegate returns -s mod N if choice is 1 and s if choice is 0.
+func (s *Scalar) CondNegate(choice int) *Scalar {
+	return &Scalar{s.e.CondNegate(choice)}
+}
+
+// IsZero returns true if the Scalar is zero.
+func (s *Scalar) IsZero() bool {
+	return s.e.IsZero() == 1
+}
+
+// Equal returns true if the two Scalars are equal. The comparison runs in
+// constant time.
+func (s *Scalar) Equal(o *Scalar) bool {
+	return s.e.Equal(o.e) == 1
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"github.com/stretchr/testify/require"
+	"math/big"
 	"testing"
 )
 
@@ -51,7 +52,7 @@ func TestSign(t *testing.T) {
 	}
 
 	for i, test := range tests {
-		name := fmt.Sprintf("%D", i)
+		name := fmt.Sprintf("%d", i)
 		t.Run(name, func(t *testing.T) {
 			sk, err := ParsePrivKeyHexString(test.sk)
 			require.NoError(t, err)
@@ -59,7 +60,7 @@ func TestSign(t *testing.T) {
 			pk, err := ParseXOnlyPubKeyHexString(test.pk)
 			require.NoError(t, err)
 
-			require.True(t, sk.PubKey.X.Equal(pk.X))
+			require.True(t, sk.PubKey.X().Equal(pk.X()))
 
 			aux := readHexString(t, test.aux)
 			msg := readHexString(t, test.msg)
@@ -146,7 +147,7 @@ func TestVerify(t *testing.T) {
 	}
 
 	for i, test := range tests {
-		name := fmt.Sprintf("%D", i)
+		name := fmt.Sprintf("%d", i)
 		t.Run(name, func(t *testing.T) {
 			pk, err := ParseXOnlyPubKeyHexString(test.pk)
 			if err != nil && !test.valid {
@@ -224,6 +225,95 @@ func TestBatchVerify(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestBatchVerifyXOnly checks that BatchVerify, like Verify, only depends on
+// the X coordinates of the public keys and of the R values, and that it fails
+// if a message is changed.
+func TestBatchVerifyXOnly(t *testing.T) {
+	var (
+		pks  []*PublicKey
+		sigs []*Signature
+		msgs [][]byte
+	)
+	for i := int64(1); len(pks) < 4; i++ {
+		sk, err := PrivateKeyFromInt(big.NewInt(i))
+		require.NoError(t, err)
+
+		// Only keys with an odd Y coordinate are used.
+		if sk.PubKey.HasEvenY() {
+			continue
+		}
+
+		msg := bytes.Repeat([]byte{byte(i)}, 32)
+		sig, err := sk.Sign(msg, msg)
+		require.NoError(t, err)
+
+		// R is replaced by its negation, which has the same x-only
+		// encoding, if it has an even Y coordinate.
+		if sig.R.HasEvenY() {
+			sig = NewSignatureFromScalar(sig.R.Neg(), sig.S)
+		}
+		require.False(t, sig.R.HasEvenY())
+		require.NoError(t, sig.Verify(sk.PubKey, msg))
+
+		pks = append(pks, sk.PubKey)
+		sigs = append(sigs, sig)
+		msgs = append(msgs, msg)
+	}
+
+	require.NoError(t, BatchVerify(pks, msgs, sigs))
+
+	// Verification fails if any of the messages is changed.
+	msgs[1] = msgs[0]
+	require.Error(t, BatchVerify(pks, msgs, sigs))
+}
+
+// TestPublicKeyNegSubDouble checks the Neg, Sub and Double methods of
+// PublicKey.
+func TestPublicKeyNegSubDouble(t *testing.T) {
+	sk, err := NewPrivateKey()
+	require.NoError(t, err)
+	pk := sk.PubKey
+
+	// The negation has the same x-only encoding but the opposite parity.
+	neg := pk.Neg()
+	require.Equal(t, pk.XOnlyBytes(), neg.XOnlyBytes())
+	require.NotEqual(t, pk.HasEvenY(), neg.HasEvenY())
+	require.NotEqual(t, pk.PlainBytes(), neg.PlainBytes())
+
+	require.True(t, pk.Sub(pk).Point.IsInfinity)
+	require.True(t, pk.Add(neg).Point.IsInfinity)
+	require.Equal(t, pk.Add(pk).PlainBytes(), pk.Double().PlainBytes())
+	require.Equal(t, pk.PlainBytes(), pk.Double().Sub(pk).PlainBytes())
+}
+
+// TestDeprecatedBigIntAPI checks that the big.Int based NewSignature and
+// IntFromBytes agree with their Scalar based replacements.
+func TestDeprecatedBigIntAPI(t *testing.T) {
+	sk, err := NewPrivateKey()
+	require.NoError(t, err)
+
+	msg := bytes.Repeat([]byte{0x01}, 32)
+	sig, err := sk.Sign(msg, msg)
+	require.NoError(t, err)
+
+	sig2, err := NewSignature(sig.R, sig.S.BigInt())
+	require.NoError(t, err)
+	require.Equal(t, sig.Bytes(), sig2.Bytes())
+	require.NoError(t, sig2.Verify(sk.PubKey, msg))
+
+	N := Secp256k1.ScalarField().Modulus()
+	_, err = NewSignature(sig.R, N)
+	require.Error(t, err)
+
+	_, err = NewSignature(sig.R, big.NewInt(-1))
+	require.Error(t, err)
+
+	var b [32]byte
+	N.FillBytes(b[:])
+	b[31]++
+	require.Equal(t, big.NewInt(1), IntFromBytes(b))
+}
+
 func readHexString(t *testing.T, s string) []byte {
 	b, err := hex.DecodeString(s)
 	require.NoError(t, err)
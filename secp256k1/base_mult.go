@@ -0,0 +1,86 @@
+package secp256k1
+
+import "sync"
+
+// baseWindows is the number of 4-bit windows in a Scalar. The precomputed
+// table for G holds one row of multiples per window.
+const baseWindows = 2 * ScalarBytesLen
+
+var (
+	// baseTableOnce guards the lazy construction of baseTable.
+	baseTableOnce sync.Once
+
+	// baseTable holds j*16^i*G in row i and column j. With it, k*G is the
+	// sum of the entries picked out by the 4-bit windows of k, so no
+	// doublings are needed at all.
+	baseTable *[baseWindows][secretTableSize]projPoint
+)
+
+// ScalarBaseMult returns k*G. It uses a table of multiples of G that is built
+// the first time it is needed and runs in constant time, so k may be secret.
+func ScalarBaseMult(k *Scalar) *Point {
+	table := getBaseTable()
+	b := k.Bytes()
+
+	var r, t projPoint
+	r.setInfinity()
+	for i := 0; i < baseWindows; i++ {
+		t.lookup(&table[i], uint64(baseWindow(&b, i)))
+		r.add(&r, &t)
+	}
+
+	return r.toPoint(new(Point))
+}
+
+// ScalarBaseMultVartime returns k*G like ScalarBaseMult but skips the table
+// lookups for zero windows and reads the table entries directly.
+//
+// NOTE: the time taken depends on the value of k so this must only be used
+// for public scalars, such as during signature verification.
+func ScalarBaseMultVartime(k *Scalar) *Point {
+	table := getBaseTable()
+	b := k.Bytes()
+
+	var r projPoint
+	r.setInfinity()
+	for i := 0; i < baseWindows; i++ {
+		if d := baseWindow(&b, i); d != 0 {
+			r.add(&r, &table[i][d])
+		}
+	}
+
+	return r.toPoint(new(Point))
+}
+
+// baseWindow returns the i-th 4-bit window of the big-endian scalar b, counting
+// from the least significant one.
+func baseWindow(b *[ScalarBytesLen]byte, i int) byte {
+	return b[ScalarBytesLen-1-i/2] >> (4 * (i % 2)) & 0xf
+}
+
+// getBaseTable returns the table of multiples of G, building it first if this
+// is the first call.
+func getBaseTable() *[baseWindows][secretTableSize]projPoint {
+	baseTableOnce.Do(func() {
+		table := new([baseWindows][secretTableSize]projPoint)
+
+		// base holds 16^i*G for the current row.
+		var base projPoint
+		base.setPoint(G)
+
+		for i := range table {
+			table[i][0].setInfinity()
+			table[i][1] = base
+
+			for j := 2; j < secretTableSize; j++ {
+				table[i][j].add(&table[i][j-1], &base)
+			}
+
+			base.add(&table[i][secretTableSize-1], &base)
+		}
+
+		baseTable = table
+	})
+
+	return baseTable
+}
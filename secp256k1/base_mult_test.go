@@ -0,0 +1,64 @@
+package secp256k1
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestScalarBaseMult checks both variants of ScalarBaseMult against
+// multiplying G directly.
+func TestScalarBaseMult(t *testing.T) {
+	for _, k := range glvTestScalars(t) {
+		exp, err := G.Point.Mul(k)
+		require.NoError(t, err)
+
+		s := ScalarFromInt(k)
+		require.True(t, exp.Equal(ScalarBaseMult(s).Point), "%x", k)
+		require.True(t, exp.Equal(ScalarBaseMultVartime(s).Point),
+			"%x", k)
+	}
+}
+
+// TestMultiScalarMulBase checks that MultiScalarMul handles terms with G,
+// including when they are the only terms.
+func TestMultiScalarMulBase(t *testing.T) {
+	a, b, c := NewScalar(3), NewScalar(5), NewScalar(7)
+	p := G.Mul(big.NewInt(11))
+
+	// 3G + 5*11G + 7G = 65G
+	res, err := MultiScalarMul([]*Point{G, p, G}, []*Scalar{a, b, c})
+	require.NoError(t, err)
+	require.True(t, G.Mul(big.NewInt(65)).Equal(res))
+
+	res, err = MultiScalarMul([]*Point{G}, []*Scalar{a})
+	require.NoError(t, err)
+	require.True(t, G.Mul(big.NewInt(3)).Equal(res))
+}
+
+func BenchmarkScalarBaseMult(b *testing.B) {
+	k := ScalarFromInt(big.NewInt(1234567))
+	getBaseTable()
+
+	b.Run("table", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			ScalarBaseMult(k)
+		}
+	})
+
+	b.Run("table-vartime", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			ScalarBaseMultVartime(k)
+		}
+	})
+
+	b.Run("mul-secret", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			G.MulSecret(k)
+		}
+	})
+}
@@ -30,7 +30,12 @@ func init() {
 	}
 	B = b
 
-	Curve = ellipticcurve.NewCurve(a.Element, b.Element)
+	Curve, err = ellipticcurve.NewCurve(a.Element, b.Element)
+	if err != nil {
+		panic("error initializing curve: " + err.Error())
+	}
 
 	pointInit()
+	glvInit()
+	hashToCurveInit()
 }
@@ -5,14 +5,48 @@ import (
 	"math/big"
 )
 
-// P is the prime of the secp256k1 finite field.
-var P *big.Int
+var (
+	// P is the prime of the secp256k1 finite field.
+	P *big.Int
+
+	// BaseField is the finite field of order P that the coordinates of
+	// the points on the curve are defined over.
+	BaseField *finitefield.Field
+
+	// baseInverter computes inverses modulo P in constant time.
+	baseInverter *finitefield.Inverter
+
+	// pMinusOne is the order of the multiplicative group of the field.
+	pMinusOne *big.Int
+
+	// sqrtExp is the exponent (P+1)/4 used to compute square roots. Since
+	// P = 3 mod 4, a single exponentiation is enough.
+	sqrtExp *big.Int
+)
 
 const p = "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F"
 
 // FieldElement is an secp256k1 Element.
+//
+// The arithmetic methods of FieldElement do not go through the generic
+// finitefield.Element arithmetic. Instead, they use a fixed-width limb
+// representation that is specialised to the secp256k1 prime. The result of an
+// operation keeps its limbs so that the next operation on it does not need to
+// convert it back from the big.Int. The embedded Element is always kept in
+// sync with the result so that FieldElement can still be used anywhere that a
+// finitefield.Element is expected, but it must only be changed through the
+// methods of FieldElement.
+//
+// Because of the limbs, a FieldElement can't be built with a positional literal
+// such as FieldElement{e}. Use NewFieldElement or a keyed literal instead.
 type FieldElement struct {
 	*finitefield.Element
+
+	// val is the value of the FieldElement in limb form. It is only set
+	// if hasVal is true, which is the case for all the FieldElements that
+	// are produced by the limb arithmetic.
+	val    fieldVal
+	hasVal bool
 }
 
 // NewFieldElement constructs a new FieldElement.
@@ -22,71 +56,131 @@ func NewFieldElement(n *big.Int) (*FieldElement, error) {
 		return nil, err
 	}
 
-	return &FieldElement{e}, nil
+	f := &FieldElement{Element: e, hasVal: true}
+	f.val.setInt(e.Num)
+
+	return f, nil
 }
 
 // Equal returns true if the passed Element is equivalent to this Element.
 func (e *FieldElement) Equal(o *FieldElement) bool {
+	if e.hasVal && o.hasVal {
+		return e.val.equal(&o.val)
+	}
+
 	return e.Element.Equal(o.Element)
 }
 
 // Add adds two Elements in the same finite field together.
 func (e *FieldElement) Add(o *FieldElement) (*FieldElement, error) {
-	res, err := e.Element.Add(o.Element)
-	if err != nil {
+	if err := e.checkField(o); err != nil {
 		return nil, err
 	}
 
-	return &FieldElement{res}, nil
+	return new(FieldElement).SetAdd(e, o), nil
 }
 
 // Sub subtracts the given Element from this Element.
 func (e *FieldElement) Sub(o *FieldElement) (*FieldElement, error) {
-	res, err := e.Element.Sub(o.Element)
-	if err != nil {
+	if err := e.checkField(o); err != nil {
 		return nil, err
 	}
 
-	return &FieldElement{res}, nil
+	return new(FieldElement).SetSub(e, o), nil
 }
 
 // Mul multiplies the two Elements together.
 func (e *FieldElement) Mul(o *FieldElement) (*FieldElement, error) {
-	res, err := e.Element.Mul(o.Element)
-	if err != nil {
+	if err := e.checkField(o); err != nil {
 		return nil, err
 	}
 
-	return &FieldElement{res}, nil
+	return new(FieldElement).SetMul(e, o), nil
 }
 
 // Pow defines exponentiation on the Element.
 func (e *FieldElement) Pow(exp *big.Int) *FieldElement {
-	res := e.Element.Pow(exp)
-
-	return &FieldElement{res}
+	return new(FieldElement).SetPow(e, exp)
 }
 
 // Div divides this FieldElement by the given FieldElement and returns the
 // resulting FieldElement.
 func (e *FieldElement) Div(o *FieldElement) (*FieldElement, error) {
-	res, err := e.Element.Div(o.Element)
-	if err != nil {
+	if err := e.checkField(o); err != nil {
 		return nil, err
 	}
 
-	return &FieldElement{res}, nil
+	return new(FieldElement).SetDiv(e, o), nil
+}
+
+// Sqrt returns a square root of the FieldElement. The other square root is its
+// negation. finitefield.ErrNoSquareRoot is returned if the FieldElement is not
+// a quadratic residue.
+func (e *FieldElement) Sqrt() (*FieldElement, error) {
+	var r fieldVal
+	a := load(e)
+	r.pow(&a, sqrtExp)
+
+	// The exponentiation always produces a value. It is only a root if it
+	// squares back to the original value.
+	var r2 fieldVal
+	if !r2.square(&r).equal(&a) {
+		return nil, finitefield.ErrNoSquareRoot
+	}
+
+	return newFieldElementFromVal(&r), nil
+}
+
+// Negate returns the additive inverse of the FieldElement.
+func (e *FieldElement) Negate() *FieldElement {
+	return new(FieldElement).SetNegate(e)
 }
 
 // IsZero returns true if the FieldElement's number is zero.
 func (e *FieldElement) IsZero() bool {
+	if e.hasVal {
+		return e.val.isZero()
+	}
+
 	return e.Element.IsZero()
 }
 
+// checkField returns an error if the two FieldElements are not both defined
+// over the secp256k1 prime.
+func (e *FieldElement) checkField(o *FieldElement) error {
+	if e.P.Cmp(P) != 0 || o.P.Cmp(P) != 0 {
+		return finitefield.ErrElementsOfDifferentFields
+	}
+
+	return nil
+}
+
+// newFieldElementFromVal constructs a new FieldElement from the given
+// fieldVal.
+func newFieldElementFromVal(f *fieldVal) *FieldElement {
+	return new(FieldElement).setVal(f)
+}
+
 func fieldInit() {
 	var ok bool
 	P, ok = new(big.Int).SetString(p, 16)
 	if !ok {
 		panic("invalid hex: " + p)
 	}
+
+	var err error
+	BaseField, err = finitefield.NewField(P)
+	if err != nil {
+		panic("could not init base field: " + err.Error())
+	}
+
+	baseInverter, err = finitefield.NewInverter(P)
+	if err != nil {
+		panic("could not init base field inverter: " + err.Error())
+	}
+
+	pMinusOne = new(big.Int).Sub(P, big.NewInt(1))
+
+	sqrtExp = new(big.Int).Add(P, big.NewInt(1))
+	sqrtExp.Rsh(sqrtExp, 2)
 }
@@ -0,0 +1,243 @@
+package secp256k1
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/ellemouton/schnorr/finitefield"
+	"github.com/stretchr/testify/require"
+)
+
+// fieldTestValues returns a set of values that exercise the edges of the limb
+// arithmetic along with a number of random values in the field.
+func fieldTestValues(t testing.TB, numRandom int) []*big.Int {
+	vals := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(2),
+		big.NewInt(pc),
+		new(big.Int).Sub(P, big.NewInt(1)),
+		new(big.Int).Sub(P, big.NewInt(2)),
+		new(big.Int).Sub(P, big.NewInt(pc)),
+		new(big.Int).Lsh(big.NewInt(1), 64),
+		new(big.Int).Lsh(big.NewInt(1), 128),
+		new(big.Int).Lsh(big.NewInt(1), 255),
+		new(big.Int).Sub(
+			new(big.Int).Lsh(big.NewInt(1), 192), big.NewInt(1),
+		),
+	}
+
+	for i := 0; i < numRandom; i++ {
+		n, err := rand.Int(rand.Reader, P)
+		require.NoError(t, err)
+
+		vals = append(vals, n)
+	}
+
+	return vals
+}
+
+// TestFieldElementDifferential checks that the limb based FieldElement
+// arithmetic matches the reference finitefield.Element arithmetic.
+func TestFieldElementDifferential(t *testing.T) {
+	vals := fieldTestValues(t, 20)
+
+	for _, x := range vals {
+		for _, y := range vals {
+			a, err := NewFieldElement(x)
+			require.NoError(t, err)
+
+			b, err := NewFieldElement(y)
+			require.NoError(t, err)
+
+			refA, err := finitefield.NewElement(x, P)
+			require.NoError(t, err)
+
+			refB, err := finitefield.NewElement(y, P)
+			require.NoError(t, err)
+
+			sum, err := a.Add(b)
+			require.NoError(t, err)
+			refSum, err := refA.Add(refB)
+			require.NoError(t, err)
+			require.True(t, sum.Element.Equal(refSum), "%x + %x", x, y)
+
+			diff, err := a.Sub(b)
+			require.NoError(t, err)
+			refDiff, err := refA.Sub(refB)
+			require.NoError(t, err)
+			require.True(t, diff.Element.Equal(refDiff), "%x - %x", x, y)
+
+			prod, err := a.Mul(b)
+			require.NoError(t, err)
+			refProd, err := refA.Mul(refB)
+			require.NoError(t, err)
+			require.True(t, prod.Element.Equal(refProd), "%x * %x", x, y)
+		}
+	}
+}
+
+// TestFieldElementPowDiv checks that the limb based FieldElement Pow and Div
+// methods match the reference finitefield.Element methods.
+func TestFieldElementPowDiv(t *testing.T) {
+	vals := fieldTestValues(t, 5)
+	exps := []*big.Int{
+		big.NewInt(0), big.NewInt(1), big.NewInt(2), big.NewInt(3),
+		big.NewInt(-1), big.NewInt(-3), P, pMinusOne,
+		new(big.Int).Sub(P, big.NewInt(2)),
+	}
+
+	for _, x := range vals {
+		a, err := NewFieldElement(x)
+		require.NoError(t, err)
+
+		refA, err := finitefield.NewElement(x, P)
+		require.NoError(t, err)
+
+		for _, exp := range exps {
+			res := a.Pow(exp)
+			refRes := refA.Pow(exp)
+			require.True(t, res.Element.Equal(refRes), "%x^%d", x, exp)
+		}
+
+		for _, y := range vals {
+			b, err := NewFieldElement(y)
+			require.NoError(t, err)
+
+			refB, err := finitefield.NewElement(y, P)
+			require.NoError(t, err)
+
+			quo, err := a.Div(b)
+			require.NoError(t, err)
+			refQuo, err := refA.Div(refB)
+			require.NoError(t, err)
+			require.True(t, quo.Element.Equal(refQuo), "%x / %x", x, y)
+		}
+	}
+}
+
+// TestFieldElementChain checks that a chain of operations, which passes the
+// limbs of each result on to the next operation, matches the reference
+// arithmetic, and that FieldElements wrapping an Element directly are decoded
+// from the Element.
+func TestFieldElementChain(t *testing.T) {
+	vals := fieldTestValues(t, 5)
+
+	for _, x := range vals {
+		a, err := NewFieldElement(x)
+		require.NoError(t, err)
+
+		refA, err := finitefield.NewElement(x, P)
+		require.NoError(t, err)
+
+		wrapped := &FieldElement{Element: refA}
+
+		// a^3 + a - 1/a
+		res := new(FieldElement).SetSquare(a)
+		res.SetMul(res, wrapped)
+		res.SetAdd(res, a)
+		res.SetSub(res, new(FieldElement).SetDiv(
+			newFieldElementFromVal(&fieldVal{1}), wrapped,
+		))
+
+		ref := refA.Pow(big.NewInt(3))
+		ref, err = ref.Add(refA)
+		require.NoError(t, err)
+		ref, err = ref.Sub(refA.Pow(big.NewInt(-1)))
+		require.NoError(t, err)
+
+		require.True(t, res.Element.Equal(ref), "%x", x)
+		require.True(t, res.Equal(&FieldElement{Element: ref}), "%x", x)
+		require.Equal(t, ref.IsZero(), res.IsZero())
+	}
+}
+
+// TestFieldElementDifferentField checks that FieldElements that are not defined
+// over P are rejected.
+func TestFieldElementDifferentField(t *testing.T) {
+	a, err := NewFieldElement(big.NewInt(3))
+	require.NoError(t, err)
+
+	e, err := finitefield.NewElement(big.NewInt(3), big.NewInt(19))
+	require.NoError(t, err)
+
+	_, err = a.Add(&FieldElement{Element: e})
+	require.ErrorIs(t, err, finitefield.ErrElementsOfDifferentFields)
+}
+
+func BenchmarkFieldElementMul(b *testing.B) {
+	vals := fieldTestValues(b, 2)
+	x, _ := NewFieldElement(vals[len(vals)-1])
+	y, _ := NewFieldElement(vals[len(vals)-2])
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = x.Mul(y)
+	}
+}
+
+func BenchmarkFieldElementMulReference(b *testing.B) {
+	vals := fieldTestValues(b, 2)
+	x, _ := finitefield.NewElement(vals[len(vals)-1], P)
+	y, _ := finitefield.NewElement(vals[len(vals)-2], P)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = x.Mul(y)
+	}
+}
+
+func BenchmarkFieldElementDiv(b *testing.B) {
+	vals := fieldTestValues(b, 2)
+	x, _ := NewFieldElement(vals[len(vals)-1])
+	y, _ := NewFieldElement(vals[len(vals)-2])
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = x.Div(y)
+	}
+}
+
+func BenchmarkFieldElementDivReference(b *testing.B) {
+	vals := fieldTestValues(b, 2)
+	x, _ := finitefield.NewElement(vals[len(vals)-1], P)
+	y, _ := finitefield.NewElement(vals[len(vals)-2], P)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = x.Div(y)
+	}
+}
+
+// TestFieldElementSqrt checks the FieldElement Sqrt and Negate methods against
+// the reference finitefield.Element methods.
+func TestFieldElementSqrt(t *testing.T) {
+	for _, x := range fieldTestValues(t, 20) {
+		a, err := NewFieldElement(x)
+		require.NoError(t, err)
+
+		refA, err := finitefield.NewElement(x, P)
+		require.NoError(t, err)
+
+		require.True(t, a.Negate().Element.Equal(refA.Negate()))
+
+		r, err := a.Sqrt()
+		if !refA.IsSquare() {
+			require.ErrorIs(t, err, finitefield.ErrNoSquareRoot)
+			continue
+		}
+		require.NoError(t, err)
+
+		refR, err := refA.Sqrt()
+		require.NoError(t, err)
+
+		require.True(t,
+			r.Element.Equal(refR) || r.Element.Equal(refR.Negate()),
+		)
+	}
+}
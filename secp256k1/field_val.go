@@ -0,0 +1,242 @@
+package secp256k1
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// pc is the constant c in P = 2^256 - c. Since 2^256 is congruent to c modulo
+// P, the high half of a 512-bit product can be folded into the low half by
+// multiplying it by c instead of doing a full division.
+const pc = 0x1000003D1
+
+// fieldVal is a fixed-width representation of an element of the secp256k1
+// base field. The value is held in four 64-bit limbs in little-endian order
+// and is always kept fully reduced, in other words it is always less than P.
+//
+// All the arithmetic on fieldVal is specialised to the form of P and does not
+// allocate.
+type fieldVal [4]uint64
+
+// setBytes sets f to the big-endian value in b reduced modulo P.
+func (f *fieldVal) setBytes(b *[32]byte) *fieldVal {
+	for i := 0; i < 4; i++ {
+		j := 32 - 8*(i+1)
+		f[i] = uint64(b[j])<<56 | uint64(b[j+1])<<48 |
+			uint64(b[j+2])<<40 | uint64(b[j+3])<<32 |
+			uint64(b[j+4])<<24 | uint64(b[j+5])<<16 |
+			uint64(b[j+6])<<8 | uint64(b[j+7])
+	}
+
+	// The value can be at most 2^256 - 1 which is less than 2P so a single
+	// conditional subtraction is enough to reduce it.
+	return f.reduce(0)
+}
+
+// putBytes writes the big-endian representation of f to b.
+func (f *fieldVal) putBytes(b *[32]byte) {
+	for i := 0; i < 4; i++ {
+		j := 32 - 8*(i+1)
+		b[j] = byte(f[i] >> 56)
+		b[j+1] = byte(f[i] >> 48)
+		b[j+2] = byte(f[i] >> 40)
+		b[j+3] = byte(f[i] >> 32)
+		b[j+4] = byte(f[i] >> 24)
+		b[j+5] = byte(f[i] >> 16)
+		b[j+6] = byte(f[i] >> 8)
+		b[j+7] = byte(f[i])
+	}
+}
+
+// setInt sets f to the value of n which must be in the range [0, P).
+func (f *fieldVal) setInt(n *big.Int) *fieldVal {
+	var b [32]byte
+	n.FillBytes(b[:])
+
+	return f.setBytes(&b)
+}
+
+// putInt sets n to the value of f and returns n. The existing backing array of
+// n is reused if it is large enough.
+func (f *fieldVal) putInt(n *big.Int) *big.Int {
+	var b [32]byte
+	f.putBytes(&b)
+
+	return n.SetBytes(b[:])
+}
+
+// reduce brings the value carry*2^256 + f, where carry is either 0 or 1, back
+// into the range [0, P). The caller must ensure that the value is less than
+// 2P.
+func (f *fieldVal) reduce(carry uint64) *fieldVal {
+	// The value is at least P if and only if f + c overflows 256 bits or
+	// if there was already a carry. In both cases the reduced value is
+	// simply the low 256 bits of f + c.
+	var t fieldVal
+	var cc uint64
+	t[0], cc = bits.Add64(f[0], pc, 0)
+	t[1], cc = bits.Add64(f[1], 0, cc)
+	t[2], cc = bits.Add64(f[2], 0, cc)
+	t[3], cc = bits.Add64(f[3], 0, cc)
+
+	mask := -(carry | cc)
+	for i := range f {
+		f[i] = (t[i] & mask) | (f[i] &^ mask)
+	}
+
+	return f
+}
+
+// add sets f = a + b mod P and returns f.
+func (f *fieldVal) add(a, b *fieldVal) *fieldVal {
+	var carry uint64
+	f[0], carry = bits.Add64(a[0], b[0], 0)
+	f[1], carry = bits.Add64(a[1], b[1], carry)
+	f[2], carry = bits.Add64(a[2], b[2], carry)
+	f[3], carry = bits.Add64(a[3], b[3], carry)
+
+	return f.reduce(carry)
+}
+
+// sub sets f = a - b mod P and returns f.
+func (f *fieldVal) sub(a, b *fieldVal) *fieldVal {
+	var borrow uint64
+	f[0], borrow = bits.Sub64(a[0], b[0], 0)
+	f[1], borrow = bits.Sub64(a[1], b[1], borrow)
+	f[2], borrow = bits.Sub64(a[2], b[2], borrow)
+	f[3], borrow = bits.Sub64(a[3], b[3], borrow)
+
+	// If the subtraction underflowed then P must be added back. Modulo
+	// 2^256 that is the same as subtracting c.
+	mask := -borrow
+	f[0], borrow = bits.Sub64(f[0], pc&mask, 0)
+	f[1], borrow = bits.Sub64(f[1], 0, borrow)
+	f[2], borrow = bits.Sub64(f[2], 0, borrow)
+	f[3], _ = bits.Sub64(f[3], 0, borrow)
+
+	return f
+}
+
+// neg sets f = -a mod P and returns f.
+func (f *fieldVal) neg(a *fieldVal) *fieldVal {
+	var zero fieldVal
+
+	return f.sub(&zero, a)
+}
+
+// mul sets f = a * b mod P and returns f.
+func (f *fieldVal) mul(a, b *fieldVal) *fieldVal {
+	var t [8]uint64
+	var c uint64
+
+	// Schoolbook multiplication, one row per limb of a.
+	c, t[0] = madd(a[0], b[0], 0, 0)
+	c, t[1] = madd(a[0], b[1], 0, c)
+	c, t[2] = madd(a[0], b[2], 0, c)
+	t[4], t[3] = madd(a[0], b[3], 0, c)
+
+	c, t[1] = madd(a[1], b[0], t[1], 0)
+	c, t[2] = madd(a[1], b[1], t[2], c)
+	c, t[3] = madd(a[1], b[2], t[3], c)
+	t[5], t[4] = madd(a[1], b[3], t[4], c)
+
+	c, t[2] = madd(a[2], b[0], t[2], 0)
+	c, t[3] = madd(a[2], b[1], t[3], c)
+	c, t[4] = madd(a[2], b[2], t[4], c)
+	t[6], t[5] = madd(a[2], b[3], t[5], c)
+
+	c, t[3] = madd(a[3], b[0], t[3], 0)
+	c, t[4] = madd(a[3], b[1], t[4], c)
+	c, t[5] = madd(a[3], b[2], t[5], c)
+	t[7], t[6] = madd(a[3], b[3], t[6], c)
+
+	return f.reduceWide(&t)
+}
+
+// madd returns the 128-bit value x*y + z + c split into its high and low 64
+// bits. The result can never overflow 128 bits.
+func madd(x, y, z, c uint64) (uint64, uint64) {
+	hi, lo := bits.Mul64(x, y)
+
+	var cc uint64
+	lo, cc = bits.Add64(lo, z, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+
+	return hi, lo
+}
+
+// square sets f = a^2 mod P and returns f.
+func (f *fieldVal) square(a *fieldVal) *fieldVal {
+	return f.mul(a, a)
+}
+
+// reduceWide sets f to the 512-bit value t reduced modulo P and returns f.
+func (f *fieldVal) reduceWide(t *[8]uint64) *fieldVal {
+	// Fold the high 256 bits into the low 256 bits using
+	// 2^256 = c mod P. The result fits in 256 + 34 bits.
+	var carry uint64
+	for i := 0; i < 4; i++ {
+		hi, lo := bits.Mul64(t[i+4], pc)
+
+		var cc uint64
+		lo, cc = bits.Add64(lo, carry, 0)
+		hi += cc
+		f[i], cc = bits.Add64(t[i], lo, 0)
+		hi += cc
+
+		carry = hi
+	}
+
+	// Fold the remaining carry in the same way. This can overflow 256
+	// bits at most once more.
+	hi, lo := bits.Mul64(carry, pc)
+
+	var cc uint64
+	f[0], cc = bits.Add64(f[0], lo, 0)
+	f[1], cc = bits.Add64(f[1], hi, cc)
+	f[2], cc = bits.Add64(f[2], 0, cc)
+	f[3], cc = bits.Add64(f[3], 0, cc)
+
+	return f.reduce(cc)
+}
+
+// pow sets f = a^exp mod P and returns f. The exponent must not be negative.
+//
+// NOTE: this runs in time that depends on the exponent and so must only be
+// used with public exponents.
+func (f *fieldVal) pow(a *fieldVal, exp *big.Int) *fieldVal {
+	base := *a
+	res := fieldVal{1}
+
+	for i := exp.BitLen() - 1; i >= 0; i-- {
+		res.square(&res)
+
+		if exp.Bit(i) == 1 {
+			res.mul(&res, &base)
+		}
+	}
+
+	*f = res
+
+	return f
+}
+
+// inverse sets f = a^-1 mod P and returns f. The inverse of zero is zero. The
+// inversion runs in constant time.
+func (f *fieldVal) inverse(a *fieldVal) *fieldVal {
+	baseInverter.Invert(f[:], a[:])
+
+	return f
+}
+
+// isZero returns true if f is zero.
+func (f *fieldVal) isZero() bool {
+	return f[0]|f[1]|f[2]|f[3] == 0
+}
+
+// equal returns true if f and o hold the same value.
+func (f *fieldVal) equal(o *fieldVal) bool {
+	return (f[0]^o[0])|(f[1]^o[1])|(f[2]^o[2])|(f[3]^o[3]) == 0
+}
@@ -0,0 +1,124 @@
+package secp256k1
+
+import (
+	"math/big"
+
+	"github.com/ellemouton/schnorr/ellipticcurve"
+)
+
+// secp256k1 has an efficiently computable endomorphism
+//
+//	phi(x, y) = (beta*x, y) = lambda*(x, y)
+//
+// where beta is a cube root of unity modulo P and lambda is a cube root of
+// unity modulo N. Following Gallant, Lambert and Vanstone (GLV), a scalar k is
+// split into two halves of about 128 bits with k = k1 + k2*lambda mod N so that
+// k*P = k1*P + k2*phi(P) can be computed as a joint multiplication with half as
+// many doublings.
+
+const (
+	beta   = "7AE96A2B657C07106E64479EAC3434E99CF0497512F58995C1396C28719501EE"
+	lambda = "5363AD4CC05C30E0A5261C028812645A122E22EA20816678DF02967C1B23BD72"
+
+	// The short basis (a1, b1), (a2, b2) of the lattice of pairs (x, y)
+	// with x + y*lambda = 0 mod N. b1 is negative and b2 = a1.
+	glvA1    = "3086D221A7D46BCDE86C90E49284EB15"
+	glvNegB1 = "E4437ED6010E88286F547FA90ABFE4C3"
+	glvA2    = "114CA50F7A8E2F3F657C1108D9D44CFD8"
+)
+
+var (
+	// endoBeta is the cube root of unity modulo P that the endomorphism
+	// multiplies the X coordinate by.
+	endoBeta *FieldElement
+
+	glvBasisA1, glvBasisB1, glvBasisA2, glvBasisB2 *big.Int
+)
+
+// mulGLV sets z to c*p using the endomorphism and returns z. c must already be
+// reduced modulo N.
+func (z *Point) mulGLV(p *Point, c *big.Int) *Point {
+	if p.IsInfinity {
+		return z.Set(p)
+	}
+
+	k1, k2 := splitScalar(c)
+
+	r, err := ellipticcurve.MultiScalarMul(
+		[]*ellipticcurve.Point{p.Point, endomorphism(p).Point},
+		[]*big.Int{k1, k2},
+	)
+	if err != nil {
+		// Both points are on the secp256k1 curve.
+		panic(err)
+	}
+
+	z.point().Set(r)
+
+	return z
+}
+
+// splitScalar returns k1 and k2 such that k = k1 + k2*lambda mod N. Both halves
+// are at most about 128 bits long in absolute value and either may be
+// negative.
+func splitScalar(k *big.Int) (*big.Int, *big.Int) {
+	// c1 = round(b2*k/N) and c2 = round(-b1*k/N)
+	c1 := roundDiv(new(big.Int).Mul(glvBasisB2, k), Curve.N())
+	c2 := roundDiv(new(big.Int).Mul(new(big.Int).Neg(glvBasisB1), k), Curve.N())
+
+	// k1 = k - c1*a1 - c2*a2
+	k1 := new(big.Int).Sub(k, new(big.Int).Mul(c1, glvBasisA1))
+	k1.Sub(k1, new(big.Int).Mul(c2, glvBasisA2))
+
+	// k2 = -c1*b1 - c2*b2
+	k2 := new(big.Int).Mul(c1, glvBasisB1)
+	k2.Neg(k2)
+	k2.Sub(k2, new(big.Int).Mul(c2, glvBasisB2))
+
+	return k1, k2
+}
+
+// roundDiv returns x/d rounded to the nearest integer. x must not be negative.
+func roundDiv(x, d *big.Int) *big.Int {
+	// floor((2x + d) / 2d)
+	n := new(big.Int).Lsh(x, 1)
+	n.Add(n, d)
+
+	return n.Quo(n, new(big.Int).Lsh(d, 1))
+}
+
+// endomorphism returns phi(p) = (beta*x, y), which equals lambda*p.
+func endomorphism(p *Point) *Point {
+	x := &FieldElement{Element: p.X()}
+	y := &FieldElement{Element: p.Y()}
+
+	q, err := NewPoint(new(FieldElement).SetMul(x, endoBeta), y)
+	if err != nil {
+		// The endomorphism maps points on the curve to points on the
+		// curve.
+		panic(err)
+	}
+
+	return q
+}
+
+func glvInit() {
+	endoBeta = &FieldElement{
+		Element: BaseField.FromBigInt(hexInt(beta)),
+	}
+
+	glvBasisA1 = hexInt(glvA1)
+	glvBasisB1 = new(big.Int).Neg(hexInt(glvNegB1))
+	glvBasisA2 = hexInt(glvA2)
+	glvBasisB2 = glvBasisA1
+}
+
+// hexInt parses the given hex constant. It panics if the constant is invalid.
+func hexInt(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("invalid hex: " + s)
+	}
+
+	return n
+}
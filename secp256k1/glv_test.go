@@ -0,0 +1,73 @@
+package secp256k1
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// endoLambda is the cube root of unity modulo N that the endomorphism
+// multiplies points by. The decomposition only needs the lattice basis so
+// lambda itself is only used to check the endomorphism.
+var endoLambda = hexInt(lambda)
+
+// glvTestScalars returns the usual Scalar test values along with values around
+// lambda and N that stress the decomposition.
+func glvTestScalars(t *testing.T) []*big.Int {
+	vals := scalarTestValues(t)
+
+	for _, base := range []*big.Int{endoLambda, Curve.N()} {
+		for d := int64(-3); d <= 3; d++ {
+			v := new(big.Int).Add(base, big.NewInt(d))
+			vals = append(vals, v.Mod(v, Curve.N()))
+		}
+	}
+
+	lambdaSq := new(big.Int).Mul(endoLambda, endoLambda)
+	vals = append(vals, lambdaSq.Mod(lambdaSq, Curve.N()))
+
+	return vals
+}
+
+// TestEndomorphism checks that the endomorphism is multiplication by lambda.
+func TestEndomorphism(t *testing.T) {
+	for _, p := range []*Point{G, G.Mul(big.NewInt(31337))} {
+		exp, err := p.Point.Mul(endoLambda)
+		require.NoError(t, err)
+		require.True(t, exp.Equal(endomorphism(p).Point))
+	}
+}
+
+// TestSplitScalar checks that the two halves recombine to the scalar and are
+// about half its length.
+func TestSplitScalar(t *testing.T) {
+	for _, k := range glvTestScalars(t) {
+		k1, k2 := splitScalar(k)
+
+		require.LessOrEqual(t, k1.BitLen(), 129, "%x", k)
+		require.LessOrEqual(t, k2.BitLen(), 129, "%x", k)
+
+		sum := new(big.Int).Mul(k2, endoLambda)
+		sum.Add(sum, k1)
+		require.Zero(t, k.Cmp(sum.Mod(sum, Curve.N())), "%x", k)
+	}
+}
+
+// TestPointMulGLV checks the GLV multiplication used by Mul against plain
+// double-and-add.
+func TestPointMulGLV(t *testing.T) {
+	points := []*Point{
+		G,
+		G.Mul(big.NewInt(31337)),
+		NewInfinityPoint(),
+	}
+
+	for _, p := range points {
+		for _, k := range glvTestScalars(t) {
+			exp, err := p.Point.Mul(k)
+			require.NoError(t, err)
+			require.True(t, exp.Equal(p.Mul(k).Point), "%x", k)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package secp256k1
+
+import (
+	"crypto/sha256"
+
+	"github.com/ellemouton/schnorr/ellipticcurve"
+	"github.com/ellemouton/schnorr/finitefield"
+)
+
+// The functions in this file implement the secp256k1_XMD:SHA-256_SSWU_RO_ and
+// secp256k1_XMD:SHA-256_SSWU_NU_ suites of RFC 9380, "Hashing to Elliptic
+// Curves" (https://www.rfc-editor.org/rfc/rfc9380.html). Since A = 0 for
+// secp256k1, the simplified SWU map can not be used on it directly. Instead,
+// field elements are mapped to the curve
+//
+//	y^2 = x^3 + A'x + B'
+//
+// which is 3-isogenous to secp256k1, and then moved across with the isogeny
+// map of appendix E.1 of the RFC. The cofactor of secp256k1 is 1 so there is
+// no cofactor to clear.
+
+const (
+	// HashToCurveSuite is the ID of the suite implemented by HashToCurve.
+	HashToCurveSuite = "secp256k1_XMD:SHA-256_SSWU_RO_"
+
+	// EncodeToCurveSuite is the ID of the suite implemented by
+	// EncodeToCurve.
+	EncodeToCurveSuite = "secp256k1_XMD:SHA-256_SSWU_NU_"
+
+	// hashToCurveSecurity is the target security level k, in bits, of
+	// the suites.
+	hashToCurveSecurity = 128
+
+	isoA = "3F8731ABDD661ADCA08A5558F0F5D272E953D363CB6F0E5D405447C01A444533"
+	isoB = 1771
+
+	// sswuZ is the constant Z of the simplified SWU map.
+	sswuZ = -11
+)
+
+// The coefficients of the polynomials of the isogeny map, in increasing order
+// of degree. The denominators are monic, so their leading coefficients of 1 are
+// left out here and added by hashToCurveInit.
+var (
+	isoXNumHex = []string{
+		"8E38E38E38E38E38E38E38E38E38E38E38E38E38E38E38E38E38E38DAAAAA8C7",
+		"07D3D4C80BC321D5B9F315CEA7FD44C5D595D2FC0BF63B92DFFF1044F17C6581",
+		"534C328D23F234E6E2A413DECA25CAECE4506144037C40314ECBD0B53D9DD262",
+		"8E38E38E38E38E38E38E38E38E38E38E38E38E38E38E38E38E38E38DAAAAA88C",
+	}
+	isoXDenHex = []string{
+		"D35771193D94918A9CA34CCBB7B640DD86CD409542F8487D9FE6B745781EB49B",
+		"EDADC6F64383DC1DF7C4B2D51B54225406D36B641F5E41BBC52A56612A8C6D14",
+	}
+	isoYNumHex = []string{
+		"4BDA12F684BDA12F684BDA12F684BDA12F684BDA12F684BDA12F684B8E38E23C",
+		"C75E0C32D5CB7C0FA9D0A54B12A0A6D5647AB046D686DA6FDFFC90FC201D71A3",
+		"29A6194691F91A73715209EF6512E576722830A201BE2018A765E85A9ECEE931",
+		"2F684BDA12F684BDA12F684BDA12F684BDA12F684BDA12F684BDA12F38E38D84",
+	}
+	isoYDenHex = []string{
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFF93B",
+		"7A06534BB8BDB49FD5E9E6632722C2989467C1BFC8E8D978DFB425D2685C2573",
+		"6484AA716545CA2CF3A70C3FA8FE337E0A3D21162F0D6299A7BF8192BFD2A76F",
+	}
+)
+
+var (
+	// isoCurve is the curve y^2 = x^3 + A'x + B' that is 3-isogenous to
+	// secp256k1.
+	isoCurve *ellipticcurve.Curve
+
+	// isoZ is the constant Z of the simplified SWU map.
+	isoZ *finitefield.Element
+
+	// The polynomials of the isogeny map.
+	isoXNum, isoXDen, isoYNum, isoYDen []*finitefield.Element
+)
+
+// HashToCurve hashes msg to a point on the curve with the domain separation
+// tag dst, using the secp256k1_XMD:SHA-256_SSWU_RO_ suite. The result is
+// indistinguishable from a random point, and nobody knows its discrete
+// logarithm.
+func HashToCurve(msg, dst []byte) (*Point, error) {
+	u, err := BaseField.HashToField(
+		sha256.New, msg, dst, 2, hashToCurveSecurity,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	q0, err := mapToCurve(u[0])
+	if err != nil {
+		return nil, err
+	}
+
+	q1, err := mapToCurve(u[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return q0.Add(q1), nil
+}
+
+// EncodeToCurve hashes msg to a point on the curve with the domain separation
+// tag dst, using the secp256k1_XMD:SHA-256_SSWU_NU_ suite. It is cheaper than
+// HashToCurve, but the result is only a non-uniform encoding: it can only be
+// one of about half of the points on the curve.
+func EncodeToCurve(msg, dst []byte) (*Point, error) {
+	u, err := BaseField.HashToField(
+		sha256.New, msg, dst, 1, hashToCurveSecurity,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapToCurve(u[0])
+}
+
+// mapToCurve maps u to a point on the curve with the simplified SWU map on the
+// isogenous curve followed by the isogeny map.
+func mapToCurve(u *finitefield.Element) (*Point, error) {
+	q, err := isoCurve.MapToCurveSSWU(u, isoZ)
+	if err != nil {
+		return nil, err
+	}
+
+	return isoMap(q)
+}
+
+// isoMap maps the point q on the isogenous curve to secp256k1 with the
+// 3-isogeny
+//
+//	(x, y) -> (xNum(x) / xDen(x), y * yNum(x) / yDen(x))
+//
+// The few points where a denominator is zero are mapped to infinity.
+func isoMap(q *ellipticcurve.Point) (*Point, error) {
+	if q.IsInfinity {
+		return NewInfinityPoint(), nil
+	}
+
+	x, y := q.X(), q.Y()
+
+	xDen := evalPoly(isoXDen, x)
+	yDen := evalPoly(isoYDen, x)
+	if xDen.IsZero() || yDen.IsZero() {
+		return NewInfinityPoint(), nil
+	}
+
+	var xOut, yOut finitefield.Element
+	xOut.SetDiv(evalPoly(isoXNum, x), xDen)
+	yOut.SetDiv(evalPoly(isoYNum, x), yDen)
+	yOut.SetMul(&yOut, y)
+
+	return NewPoint(
+		&FieldElement{Element: &xOut}, &FieldElement{Element: &yOut},
+	)
+}
+
+// evalPoly evaluates the polynomial with the given coefficients, in increasing
+// order of degree, at x using Horner's method.
+func evalPoly(coeffs []*finitefield.Element,
+	x *finitefield.Element) *finitefield.Element {
+
+	r := new(finitefield.Element).Set(coeffs[len(coeffs)-1])
+	for i := len(coeffs) - 2; i >= 0; i-- {
+		r.SetMul(r, x)
+		r.SetAdd(r, coeffs[i])
+	}
+
+	return r
+}
+
+func hashToCurveInit() {
+	a := BaseField.FromBigInt(hexInt(isoA))
+	b := BaseField.FromInt(isoB)
+
+	var err error
+	isoCurve, err = ellipticcurve.NewCurve(a, b)
+	if err != nil {
+		panic("error initializing isogenous curve: " + err.Error())
+	}
+
+	isoZ = BaseField.FromInt(sswuZ)
+
+	isoXNum = fieldElements(isoXNumHex)
+	isoXDen = append(fieldElements(isoXDenHex), BaseField.One())
+	isoYNum = fieldElements(isoYNumHex)
+	isoYDen = append(fieldElements(isoYDenHex), BaseField.One())
+}
+
+// fieldElements parses the given hex constants as Elements of the base field.
+func fieldElements(hexes []string) []*finitefield.Element {
+	elements := make([]*finitefield.Element, len(hexes))
+	for i, h := range hexes {
+		elements[i] = BaseField.FromBigInt(hexInt(h))
+	}
+
+	return elements
+}
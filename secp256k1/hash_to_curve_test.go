@@ -0,0 +1,122 @@
+package secp256k1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ellemouton/schnorr/finitefield"
+	"github.com/stretchr/testify/require"
+)
+
+// hashToCurveTest is a test vector from appendix J.8 of RFC 9380.
+type hashToCurveTest struct {
+	msg  string
+	x, y string
+}
+
+// TestHashToCurve checks HashToCurve against the test vectors of appendix
+// J.8.1 of RFC 9380.
+func TestHashToCurve(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-" + HashToCurveSuite)
+
+	tests := []hashToCurveTest{
+		{
+			msg: "",
+			x:   "c1cae290e291aee617ebaef1be6d73861479c48b841eaba9b7b5852ddfeb1346",
+			y:   "64fa678e07ae116126f08b022a94af6de15985c996c3a91b64c406a960e51067",
+		},
+		{
+			msg: "abc",
+			x:   "3377e01eab42db296b512293120c6cee72b6ecf9f9205760bd9ff11fb3cb2c4b",
+			y:   "7f95890f33efebd1044d382a01b1bee0900fb6116f94688d487c6c7b9c8371f6",
+		},
+		{
+			msg: "abcdef0123456789",
+			x:   "bac54083f293f1fe08e4a70137260aa90783a5cb84d3f35848b324d0674b0e3a",
+			y:   "4436476085d4c3c4508b60fcf4389c40176adce756b398bdee27bca19758d828",
+		},
+		{
+			msg: "q128_" + strings.Repeat("q", 128),
+			x:   "e2167bc785333a37aa562f021f1e881defb853839babf52a7f72b102e41890e9",
+			y:   "f2401dd95cc35867ffed4f367cd564763719fbc6a53e969fb8496a1e6685d873",
+		},
+		{
+			msg: "a512_" + strings.Repeat("a", 512),
+			x:   "e3c8d35aaaf0b9b647e88a0a0a7ee5d5bed5ad38238152e4e6fd8c1f8cb7c998",
+			y:   "8446eeb6181bf12f56a9d24e262221cc2f0c4725c7e3803024b5888ee5823aa6",
+		},
+	}
+
+	for _, test := range tests {
+		p, err := HashToCurve([]byte(test.msg), dst)
+		require.NoError(t, err)
+		requirePoint(t, test, p)
+	}
+
+	// The empty message is hashed to the field elements u0 and u1 of the
+	// first test vector.
+	u, err := BaseField.HashToField(sha256.New, nil, dst, 2, 128)
+	require.NoError(t, err)
+	require.Equal(t,
+		"6b0f9910dd2ba71c78f2ee9f04d73b5f4c5f7fc773a701abea1e573cab002fb3",
+		hex.EncodeToString(u[0].Bytes()),
+	)
+	require.Equal(t,
+		"1ae6c212e08fe1a5937f6202f929a2cc8ef4ee5b9782db68b0d5799fd8f09e16",
+		hex.EncodeToString(u[1].Bytes()),
+	)
+
+	_, err = HashToCurve([]byte("abc"), nil)
+	require.ErrorIs(t, err, finitefield.ErrEmptyDST)
+}
+
+// TestEncodeToCurve checks EncodeToCurve against the test vectors of appendix
+// J.8.2 of RFC 9380.
+func TestEncodeToCurve(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-" + EncodeToCurveSuite)
+
+	tests := []hashToCurveTest{
+		{
+			msg: "",
+			x:   "a4792346075feae77ac3b30026f99c1441b4ecf666ded19b7522cf65c4c55c5b",
+			y:   "62c59e2a6aeed1b23be5883e833912b08ba06be7f57c0e9cdc663f31639ff3a7",
+		},
+		{
+			msg: "abc",
+			x:   "3f3b5842033fff837d504bb4ce2a372bfeadbdbd84a1d2b678b6e1d7ee426b9d",
+			y:   "902910d1fef15d8ae2006fc84f2a5a7bda0e0407dc913062c3a493c4f5d876a5",
+		},
+		{
+			msg: "abcdef0123456789",
+			x:   "07644fa6281c694709f53bdd21bed94dab995671e4a8cd1904ec4aa50c59bfdf",
+			y:   "c79f8d1dad79b6540426922f7fbc9579c3018dafeffcd4552b1626b506c21e7b",
+		},
+		{
+			msg: "q128_" + strings.Repeat("q", 128),
+			x:   "b734f05e9b9709ab631d960fa26d669c4aeaea64ae62004b9d34f483aa9acc33",
+			y:   "03fc8a4a5a78632e2eb4d8460d69ff33c1d72574b79a35e402e801f2d0b1d6ee",
+		},
+		{
+			msg: "a512_" + strings.Repeat("a", 512),
+			x:   "17d22b867658977b5002dbe8d0ee70a8cfddec3eec50fb93f36136070fd9fa6c",
+			y:   "e9178ff02f4dab73480f8dd590328aea99856a7b6cc8e5a6cdf289ecc2a51718",
+		},
+	}
+
+	for _, test := range tests {
+		p, err := EncodeToCurve([]byte(test.msg), dst)
+		require.NoError(t, err)
+		requirePoint(t, test, p)
+	}
+}
+
+// requirePoint checks that p has the coordinates of the test vector.
+func requirePoint(t *testing.T, test hashToCurveTest, p *Point) {
+	t.Helper()
+
+	require.False(t, p.IsInfinity)
+	require.Equal(t, test.x, hex.EncodeToString(p.X().Bytes()), test.msg)
+	require.Equal(t, test.y, hex.EncodeToString(p.Y().Bytes()), test.msg)
+}
@@ -0,0 +1,184 @@
+package secp256k1
+
+import (
+	"math/big"
+
+	"github.com/ellemouton/schnorr/ellipticcurve"
+	"github.com/ellemouton/schnorr/finitefield"
+)
+
+// The methods in this file are in-place variants of the FieldElement and Point
+// arithmetic in the style of math/big: z.SetAdd(x, y) sets z to x + y and
+// returns z. They follow the same rules as the in-place methods of
+// finitefield.Element and ellipticcurve.Point: the receiver may alias the
+// operands, the receiver must own its storage and the methods panic if the
+// operands are not in the secp256k1 field or on the secp256k1 curve.
+
+// Set sets z to x and returns z.
+func (z *FieldElement) Set(x *FieldElement) *FieldElement {
+	z.element().Set(x.Element)
+	z.val, z.hasVal = x.val, x.hasVal
+
+	return z
+}
+
+// SetAdd sets z to x + y and returns z.
+func (z *FieldElement) SetAdd(x, y *FieldElement) *FieldElement {
+	a, b := loadPair(x, y)
+
+	return z.setVal(a.add(&a, &b))
+}
+
+// SetSub sets z to x - y and returns z.
+func (z *FieldElement) SetSub(x, y *FieldElement) *FieldElement {
+	a, b := loadPair(x, y)
+
+	return z.setVal(a.sub(&a, &b))
+}
+
+// SetMul sets z to x * y and returns z.
+func (z *FieldElement) SetMul(x, y *FieldElement) *FieldElement {
+	a, b := loadPair(x, y)
+
+	return z.setVal(a.mul(&a, &b))
+}
+
+// SetSquare sets z to x * x and returns z.
+func (z *FieldElement) SetSquare(x *FieldElement) *FieldElement {
+	a := load(x)
+
+	return z.setVal(a.square(&a))
+}
+
+// SetNegate sets z to -x and returns z.
+func (z *FieldElement) SetNegate(x *FieldElement) *FieldElement {
+	a := load(x)
+
+	return z.setVal(a.neg(&a))
+}
+
+// SetPow sets z to x raised to the given exponent and returns z. The exponent
+// is reduced modulo P-1 so it may be negative.
+func (z *FieldElement) SetPow(x *FieldElement, exp *big.Int) *FieldElement {
+	var n big.Int
+	n.Mod(exp, pMinusOne)
+
+	a := load(x)
+
+	return z.setVal(a.pow(&a, &n))
+}
+
+// SetDiv sets z to x / y and returns z. Dividing by zero gives zero.
+func (z *FieldElement) SetDiv(x, y *FieldElement) *FieldElement {
+	a, b := loadPair(x, y)
+	b.inverse(&b)
+
+	return z.setVal(a.mul(&a, &b))
+}
+
+// setVal sets z to the value of f and returns z. The limbs are kept in z and
+// are also written to the embedded Element, reusing its big.Int.
+func (z *FieldElement) setVal(f *fieldVal) *FieldElement {
+	z.val, z.hasVal = *f, true
+
+	e := z.element()
+	if e.Num == nil {
+		e.Num = new(big.Int)
+	}
+	f.putInt(e.Num)
+	e.P = P
+
+	return z
+}
+
+// element returns the Element embedded in z, allocating it first if z is the
+// zero value.
+func (z *FieldElement) element() *finitefield.Element {
+	if z.Element == nil {
+		z.Element = new(finitefield.Element)
+	}
+
+	return z.Element
+}
+
+// load returns the fieldVal of x. The limbs of x are used as they are if it has
+// them, otherwise they are decoded from its Element. It panics if x is not in
+// the secp256k1 field.
+func load(x *FieldElement) fieldVal {
+	if x.hasVal {
+		return x.val
+	}
+
+	if x.P.Cmp(P) != 0 {
+		panic(finitefield.ErrElementsOfDifferentFields)
+	}
+
+	var f fieldVal
+	f.setInt(x.Num)
+
+	return f
+}
+
+// loadPair returns the fieldVals of x and y. It panics if they are not both in
+// the secp256k1 field.
+func loadPair(x, y *FieldElement) (fieldVal, fieldVal) {
+	return load(x), load(y)
+}
+
+// Set sets z to p and returns z.
+func (z *Point) Set(p *Point) *Point {
+	z.point().Set(p.Point)
+
+	return z
+}
+
+// SetAdd sets z to p + q and returns z.
+func (z *Point) SetAdd(p, q *Point) *Point {
+	z.point().SetAdd(p.Point, q.Point)
+
+	return z
+}
+
+// SetSub sets z to p - q and returns z.
+func (z *Point) SetSub(p, q *Point) *Point {
+	z.point().SetSub(p.Point, q.Point)
+
+	return z
+}
+
+// SetNeg sets z to -p and returns z.
+func (z *Point) SetNeg(p *Point) *Point {
+	z.point().SetNeg(p.Point)
+
+	return z
+}
+
+// SetDouble sets z to p + p and returns z.
+func (z *Point) SetDouble(p *Point) *Point {
+	z.point().SetDouble(p.Point)
+
+	return z
+}
+
+// SetMul sets z to c*p and returns z. The scalar is reduced modulo N first and
+// then split in two with the GLV endomorphism so that the multiplication is a
+// joint wNAF multiplication of two half-length scalars.
+//
+// NOTE: the time taken depends on the value of c so this must only be used
+// for public scalars.
+func (z *Point) SetMul(p *Point, c *big.Int) *Point {
+	var coef big.Int
+	coef.Mod(c, Curve.N())
+
+	return z.mulGLV(p, &coef)
+}
+
+// point returns the ellipticcurve.Point embedded in z, allocating it first if z
+// is the zero value.
+func (z *Point) point() *ellipticcurve.Point {
+	if z.Point == nil {
+		z.Point = new(ellipticcurve.Point)
+	}
+
+	return z.Point
+}
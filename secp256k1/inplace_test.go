@@ -0,0 +1,107 @@
+package secp256k1
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestFieldElementInPlace checks the in-place FieldElement methods against the
+// methods that return a new FieldElement, including when the receiver aliases
+// an operand.
+func TestFieldElementInPlace(t *testing.T) {
+	vals := fieldTestValues(t, 4)
+
+	for _, x := range vals {
+		a, err := NewFieldElement(x)
+		require.NoError(t, err)
+
+		for _, y := range vals {
+			b, err := NewFieldElement(y)
+			require.NoError(t, err)
+
+			sum, err := a.Add(b)
+			require.NoError(t, err)
+			z := new(FieldElement).Set(a)
+			require.True(t, sum.Equal(z.SetAdd(z, b)))
+
+			diff, err := a.Sub(b)
+			require.NoError(t, err)
+			z = new(FieldElement).Set(b)
+			require.True(t, diff.Equal(z.SetSub(a, z)))
+
+			prod, err := a.Mul(b)
+			require.NoError(t, err)
+			z = new(FieldElement).Set(a)
+			require.True(t, prod.Equal(z.SetMul(z, b)))
+
+			quo, err := a.Div(b)
+			require.NoError(t, err)
+			require.True(t, quo.Equal(new(FieldElement).SetDiv(a, b)))
+		}
+
+		sq, err := a.Mul(a)
+		require.NoError(t, err)
+		z := new(FieldElement).Set(a)
+		require.True(t, sq.Equal(z.SetSquare(z)))
+
+		z = new(FieldElement).Set(a)
+		require.True(t, a.Negate().Equal(z.SetNegate(z)))
+
+		// The operand is never modified.
+		require.Zero(t, a.Num.Cmp(x))
+	}
+}
+
+// TestPointInPlace checks the in-place Point methods against the methods that
+// return a new Point.
+func TestPointInPlace(t *testing.T) {
+	twoG := G.Add(G)
+
+	z := G.Copy()
+	require.True(t, twoG.Equal(z.SetAdd(z, z)))
+
+	z = G.Copy()
+	require.True(t, twoG.Equal(z.SetMul(z, big.NewInt(2))))
+
+	// The scalar is reduced modulo N.
+	z = new(Point).SetMul(G, new(big.Int).Add(Curve.N(), big.NewInt(2)))
+	require.True(t, twoG.Equal(z))
+
+	// Neither operation touched the generator.
+	require.True(t, G.Equal(new(Point).Set(G)))
+	require.True(t, Curve.Contains(G.X(), G.Y()))
+}
+
+func BenchmarkFieldElementSetMul(b *testing.B) {
+	vals := fieldTestValues(b, 2)
+	x, _ := NewFieldElement(vals[len(vals)-1])
+	y, _ := NewFieldElement(vals[len(vals)-2])
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.SetMul(x, y)
+	}
+}
+
+func BenchmarkPointAdd(b *testing.B) {
+	p, q := G.Copy(), G.Add(G)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p = p.Add(q)
+	}
+}
+
+func BenchmarkPointSetAdd(b *testing.B) {
+	p, q := G.Copy(), G.Add(G)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.SetAdd(p, q)
+	}
+}
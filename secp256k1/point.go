@@ -2,6 +2,7 @@ package secp256k1
 
 import (
 	"github.com/ellemouton/schnorr/ellipticcurve"
+	"github.com/ellemouton/schnorr/finitefield"
 	"math/big"
 )
 
@@ -12,11 +13,14 @@ const (
 )
 
 var (
-	// G is the generator point of the curve.
+	// G is the generator point of the curve. It wraps Curve.G(), and the
+	// order of the group that it generates is Curve.N().
 	G *Point
 
-	// N is the order of the group generated by G.
-	N *big.Int
+	// ScalarField is the finite field of order Curve.N(). Scalars that
+	// points are multiplied by, such as private keys, are elements of this
+	// field.
+	ScalarField *finitefield.Field
 )
 
 // Point is a point on the secp256k1 curve.
@@ -34,6 +38,17 @@ func NewPoint(x, y *FieldElement) (*Point, error) {
 	return &Point{p}, nil
 }
 
+// ParsePoint decodes a Point from any of its SEC1 encodings. See
+// ellipticcurve.ParsePoint.
+func ParsePoint(b []byte) (*Point, error) {
+	p, err := ellipticcurve.ParsePoint(b, Curve)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Point{p}, nil
+}
+
 // NewInfinityPoint constructs a new Point at infinity.
 func NewInfinityPoint() *Point {
 	p := ellipticcurve.NewInfinityPoint(Curve)
@@ -41,21 +56,11 @@ func NewInfinityPoint() *Point {
 	return &Point{p}
 }
 
-// Mul does scalar multiplication on the point.
+// Mul does scalar multiplication on the point using the GLV endomorphism and
+// wNAF. Like SetMul, it must only be used for public scalars. MulSecret must be
+// used for secret ones.
 func (p *Point) Mul(c *big.Int) *Point {
-	var coef big.Int
-	coef.Mod(c, N)
-
-	point, err := p.Point.Mul(&coef)
-	if err != nil {
-		// Mul will only ever error if the points being added are not
-		// on the same curve or the elements of the points are on
-		// different finite fields. Point guarantees that the same curve
-		// and same finite field.
-		panic(err)
-	}
-
-	return &Point{point}
+	return new(Point).SetMul(p, c)
 }
 
 // Copy returns a copy of the Point.
@@ -72,25 +77,80 @@ func (p *Point) Equal(o *Point) bool {
 
 // Add adds the two points together.
 func (p *Point) Add(o *Point) *Point {
-	res, err := p.Point.Add(o.Point)
+	return new(Point).SetAdd(p, o)
+}
+
+// Sub subtracts the given point from this point.
+func (p *Point) Sub(o *Point) *Point {
+	return new(Point).SetSub(p, o)
+}
+
+// Neg returns the inverse of the point, -p.
+func (p *Point) Neg() *Point {
+	return new(Point).SetNeg(p)
+}
+
+// Double returns p + p.
+func (p *Point) Double() *Point {
+	return new(Point).SetDouble(p)
+}
+
+// MultiScalarMul returns the sum of the given points each multiplied by the
+// Scalar at the same index. See ellipticcurve.MultiScalarMul. Any terms with a
+// point equal to the generator G are computed with the precomputed table of
+// ScalarBaseMult.
+//
+// NOTE: the time taken depends on the values of the Scalars so this must only
+// be used for public scalars.
+func MultiScalarMul(points []*Point, scalars []*Scalar) (*Point, error) {
+	if len(points) != len(scalars) {
+		return nil, ellipticcurve.ErrLengthMismatch
+	}
+
+	if len(points) == 0 {
+		return nil, ellipticcurve.ErrNoPoints
+	}
+
+	var (
+		baseK = NewScalar(0)
+		ps    = make([]*ellipticcurve.Point, 0, len(points))
+		ks    = make([]*big.Int, 0, len(scalars))
+	)
+	for i, p := range points {
+		if p.Equal(G) {
+			baseK = baseK.Add(scalars[i])
+			continue
+		}
+
+		ps = append(ps, p.Point)
+		ks = append(ks, scalars[i].BigInt())
+	}
+
+	res := ScalarBaseMultVartime(baseK)
+	if len(ps) == 0 {
+		return res, nil
+	}
+
+	p, err := ellipticcurve.MultiScalarMul(ps, ks)
 	if err != nil {
-		// Add will only ever error if the points being added are not
-		// on the same curve or the elements of the points are on
-		// different finite fields. Point guarantees that the same
-		// curve and same finite field.
-		panic(err)
+		return nil, err
 	}
 
-	return &Point{res}
+	return res.SetAdd(res, &Point{p}), nil
 }
 
 func pointInit() {
-	var ok bool
-	N, ok = new(big.Int).SetString(n, 16)
+	order, ok := new(big.Int).SetString(n, 16)
 	if !ok {
 		panic("invalid hex: " + n)
 	}
 
+	var err error
+	ScalarField, err = finitefield.NewField(order)
+	if err != nil {
+		panic("could not init scalar field: " + err.Error())
+	}
+
 	gX, ok := new(big.Int).SetString(gx, 16)
 	if !ok {
 		panic("invalid hex: " + gx)
@@ -111,8 +171,13 @@ func pointInit() {
 		panic("could not make FieldElement for Gy: " + err.Error())
 	}
 
-	G, err = NewPoint(gXFe, gYFe)
+	// secp256k1 has prime order so the cofactor is one.
+	Curve, err = Curve.WithGenerator(
+		gXFe.Element, gYFe.Element, order, big.NewInt(1),
+	)
 	if err != nil {
 		panic("could not init generator point: " + err.Error())
 	}
+
+	G = &Point{Curve.G()}
 }
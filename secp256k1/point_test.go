@@ -1,17 +1,178 @@
 package secp256k1
 
 import (
-	"github.com/stretchr/testify/require"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
 	"testing"
+
+	"github.com/ellemouton/schnorr/ellipticcurve"
+	"github.com/stretchr/testify/require"
 )
 
 // TestBasics shows that the various curve constants behave as expected.
 func TestBasics(t *testing.T) {
 	// Show that nG = infinity.
-	res := G.Mul(N)
+	res := G.Mul(Curve.N())
 	require.True(t, res.IsInfinity)
 	require.True(t, res.Equal(NewInfinityPoint()))
 
 	// Show that G is on the curve.
-	require.True(t, Curve.Contains(G.X, G.Y))
+	require.True(t, Curve.Contains(G.X(), G.Y()))
+
+	// Show that the base and scalar fields have the expected orders.
+	require.Equal(t, P, BaseField.Modulus())
+	require.Equal(t, Curve.N(), ScalarField.Modulus())
+	require.True(t, BaseField.Contains(G.X()))
+}
+
+// TestParsePoint checks the SEC1 encodings of G and of a point with an odd Y
+// coordinate.
+func TestParsePoint(t *testing.T) {
+	gx := "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"
+	gy := "483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8"
+
+	require.Equal(t, "02"+gx, hex.EncodeToString(
+		G.Encode(ellipticcurve.FormatCompressed),
+	))
+	require.Equal(t, "04"+gx+gy, hex.EncodeToString(
+		G.Encode(ellipticcurve.FormatUncompressed),
+	))
+	require.Equal(t, "06"+gx+gy, hex.EncodeToString(
+		G.Encode(ellipticcurve.FormatHybrid),
+	))
+
+	negG := G.Neg()
+	for _, p := range []*Point{G, negG} {
+		for _, format := range []ellipticcurve.PointFormat{
+			ellipticcurve.FormatCompressed,
+			ellipticcurve.FormatUncompressed,
+			ellipticcurve.FormatHybrid,
+		} {
+			dec, err := ParsePoint(p.Encode(format))
+			require.NoError(t, err)
+			require.True(t, p.Equal(dec))
+		}
+	}
+
+	b := negG.Encode(ellipticcurve.FormatCompressed)
+	require.Equal(t, byte(0x03), b[0])
+}
+
+// TestPointMul checks that the wNAF multiplication used by Mul agrees with
+// plain double-and-add.
+func TestPointMul(t *testing.T) {
+	p := G.Mul(big.NewInt(1234567))
+
+	for _, c := range scalarTestValues(t) {
+		exp, err := p.Point.Mul(c)
+		require.NoError(t, err)
+		require.True(t, exp.Equal(p.Mul(c).Point), "%x", c)
+	}
+}
+
+// TestPointNegSubDouble checks Neg, Sub and Double against multiplication by
+// the equivalent scalars.
+func TestPointNegSubDouble(t *testing.T) {
+	a, b := big.NewInt(1234567), big.NewInt(7654321)
+	p, q := G.Mul(a), G.Mul(b)
+
+	nMinusA := new(big.Int).Sub(Curve.N(), a)
+	require.True(t, p.Neg().Equal(G.Mul(nMinusA)))
+	require.True(t, p.Sub(q).Equal(G.Mul(new(big.Int).Sub(a, b))))
+	require.True(t, p.Double().Equal(G.Mul(new(big.Int).Lsh(a, 1))))
+	require.True(t, p.Double().Equal(p.Add(p)))
+
+	require.True(t, p.Sub(p).IsInfinity)
+	require.True(t, p.Add(p.Neg()).IsInfinity)
+
+	inf := NewInfinityPoint()
+	require.True(t, inf.Neg().IsInfinity)
+	require.True(t, inf.Double().IsInfinity)
+	require.True(t, inf.Sub(p).Equal(p.Neg()))
+}
+
+// BenchmarkPointMul compares double-and-add and GLV with wNAF multiplication
+// for a range of window widths.
+func BenchmarkPointMul(b *testing.B) {
+	c, err := rand.Int(rand.Reader, Curve.N())
+	require.NoError(b, err)
+
+	p := G.Mul(big.NewInt(1234567))
+
+	b.Run("double-and-add", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = p.Point.Mul(c)
+		}
+	})
+
+	b.Run("glv", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			p.Mul(c)
+		}
+	})
+
+	for w := uint(ellipticcurve.MinWindow); w <= 7; w++ {
+		b.Run(fmt.Sprintf("wnaf-%d", w), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = p.MulWNAF(c, w)
+			}
+		})
+	}
+}
+
+// TestMultiScalarMul checks MultiScalarMul against separate multiplications.
+func TestMultiScalarMul(t *testing.T) {
+	vals := scalarTestValues(t)
+
+	points := make([]*Point, len(vals))
+	scalars := make([]*Scalar, len(vals))
+	exp := NewInfinityPoint()
+	for i, v := range vals {
+		points[i] = G.Mul(big.NewInt(int64(i + 1)))
+		scalars[i] = ScalarFromInt(v)
+		exp = exp.Add(points[i].Mul(v))
+	}
+
+	res, err := MultiScalarMul(points, scalars)
+	require.NoError(t, err)
+	require.True(t, exp.Equal(res))
+
+	_, err = MultiScalarMul(points, scalars[1:])
+	require.ErrorIs(t, err, ellipticcurve.ErrLengthMismatch)
+}
+
+// BenchmarkMultiScalarMul compares MultiScalarMul with separate
+// multiplications for a range of sizes.
+func BenchmarkMultiScalarMul(b *testing.B) {
+	for _, n := range []int{2, 16, 64, 256} {
+		points := make([]*Point, n)
+		scalars := make([]*Scalar, n)
+		for i := range points {
+			k, err := rand.Int(rand.Reader, Curve.N())
+			require.NoError(b, err)
+
+			points[i] = G.Mul(k)
+			scalars[i] = ScalarFromInt(k)
+		}
+
+		b.Run(fmt.Sprintf("separate-%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sum := NewInfinityPoint()
+				for j, p := range points {
+					sum.SetAdd(sum, p.Mul(scalars[j].BigInt()))
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("msm-%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = MultiScalarMul(points, scalars)
+			}
+		})
+	}
 }
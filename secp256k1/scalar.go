@@ -0,0 +1,172 @@
+package secp256k1
+
+import (
+	"crypto/subtle"
+	"errors"
+	"math/big"
+	"math/bits"
+
+	"github.com/ellemouton/schnorr/finitefield"
+)
+
+// ScalarBytesLen is the length of the fixed-width encoding of a Scalar.
+const ScalarBytesLen = 32
+
+// ErrScalarOutOfRange is returned when a Scalar is decoded from bytes that do
+// not hold a value less than N.
+var ErrScalarOutOfRange = errors.New("scalar out of range")
+
+// Scalar is an integer modulo the group order N. Private keys, nonces,
+// challenges and signature values are all Scalars.
+//
+// Scalar is backed by a finitefield.SecretElement so all of its arithmetic,
+// including the comparisons, runs in constant time and Scalars can hold secret
+// values. A Scalar is immutable: every method returns a new Scalar.
+type Scalar struct {
+	e *finitefield.SecretElement
+}
+
+// NewScalar constructs a new Scalar from the given small integer.
+func NewScalar(v uint64) *Scalar {
+	var b [ScalarBytesLen]byte
+	new(big.Int).SetUint64(v).FillBytes(b[:])
+
+	return ScalarFromBytesReduce(b)
+}
+
+// ScalarFromInt constructs a new Scalar that is congruent to n modulo N. n may
+// be negative or larger than N.
+//
+// NOTE: big.Int values are not handled in constant time so this should only be
+// used for public values.
+func ScalarFromInt(n *big.Int) *Scalar {
+	var (
+		r big.Int
+		b [ScalarBytesLen]byte
+	)
+	r.Mod(n, Curve.N()).FillBytes(b[:])
+
+	return ScalarFromBytesReduce(b)
+}
+
+// ScalarFromBytes constructs a new Scalar from its 32 byte big-endian encoding.
+// ErrScalarOutOfRange is returned if the encoded value is not less than N.
+func ScalarFromBytes(b []byte) (*Scalar, error) {
+	if len(b) != ScalarBytesLen {
+		return nil, errors.New("scalar must be 32 bytes")
+	}
+
+	// Reduce the value and check that the reduction did not change it.
+	s := &Scalar{ScalarField.SecretFromBytes(b)}
+	if subtle.ConstantTimeCompare(s.e.Bytes(), b) != 1 {
+		return nil, ErrScalarOutOfRange
+	}
+
+	return s, nil
+}
+
+// ScalarFromBytesReduce constructs a new Scalar from the 32 byte big-endian
+// value reduced modulo N. This is the int(b) mod n operation used to turn hash
+// outputs into Scalars.
+func ScalarFromBytesReduce(b [ScalarBytesLen]byte) *Scalar {
+	return &Scalar{ScalarField.SecretFromBytes(b[:])}
+}
+
+// Bytes returns the 32 byte big-endian encoding of the Scalar.
+func (s *Scalar) Bytes() [ScalarBytesLen]byte {
+	var b [ScalarBytesLen]byte
+	copy(b[:], s.e.Bytes())
+
+	return b
+}
+
+// BigInt returns the value of the Scalar as a big.Int in the range [0, N).
+func (s *Scalar) BigInt() *big.Int {
+	return s.e.Element().Num
+}
+
+// Add returns s + o mod N.
+func (s *Scalar) Add(o *Scalar) *Scalar {
+	res, err := s.e.Add(o.e)
+	if err != nil {
+		// All Scalars are in the field of order N so this can never
+		// happen.
+		panic(err)
+	}
+
+	return &Scalar{res}
+}
+
+// Sub returns s - o mod N.
+func (s *Scalar) Sub(o *Scalar) *Scalar {
+	res, err := s.e.Sub(o.e)
+	if err != nil {
+		panic(err)
+	}
+
+	return &Scalar{res}
+}
+
+// Mul returns s * o mod N.
+func (s *Scalar) Mul(o *Scalar) *Scalar {
+	res, err := s.e.Mul(o.e)
+	if err != nil {
+		panic(err)
+	}
+
+	return &Scalar{res}
+}
+
+// Negate returns -s mod N.
+func (s *Scalar) Negate() *Scalar {
+	return &Scalar{s.e.Negate()}
+}
+
+// CondNegate returns -s mod N if choice is 1 and s if choice is 0.
+func (s *Scalar) CondNegate(choice int) *Scalar {
+	return &Scalar{s.e.CondNegate(choice)}
+}
+
+// Invert returns the multiplicative inverse of s mod N. The inverse of zero is
+// zero.
+func (s *Scalar) Invert() *Scalar {
+	return &Scalar{s.e.Inverse()}
+}
+
+// IsZero returns true if the Scalar is zero.
+func (s *Scalar) IsZero() bool {
+	return s.e.IsZero() == 1
+}
+
+// IsHigh returns true if the Scalar is greater than N/2, meaning that its
+// negation is the smaller of the two.
+func (s *Scalar) IsHigh() bool {
+	b := s.e.Bytes()
+
+	// The Scalar is high if subtracting it from (N-1)/2 borrows.
+	var borrow uint64
+	for i := 0; i < ScalarBytesLen/8; i++ {
+		off := ScalarBytesLen - 8*(i+1)
+
+		var limb uint64
+		for _, c := range b[off : off+8] {
+			limb = limb<<8 | uint64(c)
+		}
+
+		_, borrow = bits.Sub64(halfN[i], limb, borrow)
+	}
+
+	return borrow == 1
+}
+
+// Equal returns true if the two Scalars are equal. The comparison runs in
+// constant time.
+func (s *Scalar) Equal(o *Scalar) bool {
+	return s.e.Equal(o.e) == 1
+}
+
+// halfN is (N-1)/2 split into little-endian 64-bit limbs.
+var halfN = [4]uint64{
+	0xdfe92f46681b20a0, 0x5d576e7357a4501d,
+	0xffffffffffffffff, 0x7fffffffffffffff,
+}
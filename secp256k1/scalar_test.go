@@ -0,0 +1,114 @@
+package secp256k1
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// scalarTestValues returns a set of values modulo N that includes the edge
+// cases along with a few random values.
+func scalarTestValues(t *testing.T) []*big.Int {
+	halfN := new(big.Int).Rsh(Curve.N(), 1)
+
+	vals := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(2),
+		halfN,
+		new(big.Int).Add(halfN, big.NewInt(1)),
+		new(big.Int).Sub(Curve.N(), big.NewInt(2)),
+		new(big.Int).Sub(Curve.N(), big.NewInt(1)),
+	}
+
+	for i := 0; i < 8; i++ {
+		n, err := rand.Int(rand.Reader, Curve.N())
+		require.NoError(t, err)
+
+		vals = append(vals, n)
+	}
+
+	return vals
+}
+
+// TestScalarArithmetic checks the Scalar arithmetic against math/big.
+func TestScalarArithmetic(t *testing.T) {
+	vals := scalarTestValues(t)
+
+	mod := func(n *big.Int) *big.Int {
+		return n.Mod(n, Curve.N())
+	}
+
+	for _, x := range vals {
+		a := ScalarFromInt(x)
+		requireScalar(t, x, a)
+
+		requireScalar(t, mod(new(big.Int).Neg(x)), a.Negate())
+
+		expHigh := x.Cmp(new(big.Int).Rsh(Curve.N(), 1)) > 0
+		require.Equal(t, expHigh, a.IsHigh(), "%x", x)
+		require.Equal(t, x.Sign() == 0, a.IsZero())
+
+		if x.Sign() != 0 {
+			inv := new(big.Int).ModInverse(x, Curve.N())
+			requireScalar(t, inv, a.Invert())
+			require.True(t, a.Mul(a.Invert()).Equal(NewScalar(1)))
+		}
+
+		for _, y := range vals {
+			b := ScalarFromInt(y)
+
+			requireScalar(t, mod(new(big.Int).Add(x, y)), a.Add(b))
+			requireScalar(t, mod(new(big.Int).Sub(x, y)), a.Sub(b))
+			requireScalar(t, mod(new(big.Int).Mul(x, y)), a.Mul(b))
+			require.Equal(t, x.Cmp(y) == 0, a.Equal(b))
+		}
+	}
+}
+
+// TestScalarFromBytes tests the strict and reducing byte constructors.
+func TestScalarFromBytes(t *testing.T) {
+	var nBytes, maxBytes [ScalarBytesLen]byte
+	Curve.N().FillBytes(nBytes[:])
+	for i := range maxBytes {
+		maxBytes[i] = 0xff
+	}
+
+	// N and anything above it is rejected by the strict constructor but
+	// reduced by the other.
+	_, err := ScalarFromBytes(nBytes[:])
+	require.ErrorIs(t, err, ErrScalarOutOfRange)
+	require.True(t, ScalarFromBytesReduce(nBytes).IsZero())
+
+	_, err = ScalarFromBytes(maxBytes[:])
+	require.ErrorIs(t, err, ErrScalarOutOfRange)
+
+	expMax := new(big.Int).SetBytes(maxBytes[:])
+	expMax.Mod(expMax, Curve.N())
+	requireScalar(t, expMax, ScalarFromBytesReduce(maxBytes))
+
+	_, err = ScalarFromBytes(nBytes[1:])
+	require.Error(t, err)
+
+	// N-1 is the largest valid Scalar and survives a round trip.
+	nMinusOne := nBytes
+	nMinusOne[ScalarBytesLen-1]--
+
+	s, err := ScalarFromBytes(nMinusOne[:])
+	require.NoError(t, err)
+	require.Equal(t, nMinusOne, s.Bytes())
+	require.True(t, s.Equal(NewScalar(1).Negate()))
+
+	// Negative numbers are reduced by ScalarFromInt.
+	require.True(t, ScalarFromInt(big.NewInt(-1)).Equal(s))
+}
+
+// requireScalar checks that the Scalar has the expected value.
+func requireScalar(t *testing.T, exp *big.Int, s *Scalar) {
+	t.Helper()
+
+	require.Zero(t, exp.Cmp(s.BigInt()), "expected %x, got %x", exp,
+		s.BigInt())
+}
@@ -0,0 +1,218 @@
+package secp256k1
+
+import (
+	"github.com/ellemouton/schnorr/ellipticcurve"
+)
+
+// The scalar multiplication in this file is for secret scalars such as private
+// keys and nonces. Unlike Mul, which branches on the bits of the scalar and
+// works on big.Int coordinates, it runs in constant time: it uses the
+// fixed-width fieldVal arithmetic, complete addition formulas that do not
+// branch on the points, a fixed window over every bit of the scalar and table
+// lookups that read every entry.
+
+const (
+	// secretWindow is the number of scalar bits handled per addition by
+	// MulSecret.
+	secretWindow = 4
+
+	// secretTableSize is the number of multiples of the point held in
+	// the table used by MulSecret.
+	secretTableSize = 1 << secretWindow
+)
+
+// b3 is 3*B, which appears in the complete addition formulas.
+var b3 = fieldVal{21}
+
+// projPoint is a point in homogeneous projective coordinates (X:Y:Z), which
+// stand for the affine point (X/Z, Y/Z). The point at infinity is (0:1:0).
+//
+// The arithmetic uses the complete formulas for a = 0 from "Complete addition
+// formulas for prime order elliptic curves" by Renes, Costello and Batina
+// (https://eprint.iacr.org/2015/1060.pdf). They are correct for every pair of
+// inputs, including doubling and the point at infinity, so they do not need to
+// branch.
+type projPoint struct {
+	x, y, z fieldVal
+}
+
+// MulSecret returns k*p. Unlike Mul, the multiplication runs in constant time
+// so it must be used whenever k is secret.
+func (p *Point) MulSecret(k *Scalar) *Point {
+	return new(Point).SetMulSecret(p, k)
+}
+
+// SetMulSecret sets z to k*p and returns z. See MulSecret.
+func (z *Point) SetMulSecret(p *Point, k *Scalar) *Point {
+	var table [secretTableSize]projPoint
+	table[0].setInfinity()
+	table[1].setPoint(p)
+	for i := 2; i < secretTableSize; i++ {
+		table[i].add(&table[i-1], &table[1])
+	}
+
+	// Walk through the scalar one window at a time starting with the
+	// most significant one.
+	b := k.Bytes()
+
+	var r, t projPoint
+	r.setInfinity()
+	for i := 0; i < 2*ScalarBytesLen; i++ {
+		for j := 0; j < secretWindow; j++ {
+			r.double(&r)
+		}
+
+		nibble := b[i/2] >> (4 * (1 - i%2)) & 0xf
+		t.lookup(&table, uint64(nibble))
+		r.add(&r, &t)
+	}
+
+	return r.toPoint(z)
+}
+
+// setInfinity sets r to the point at infinity and returns r.
+func (r *projPoint) setInfinity() *projPoint {
+	*r = projPoint{y: fieldVal{1}}
+
+	return r
+}
+
+// setPoint sets r to the given point and returns r.
+func (r *projPoint) setPoint(p *Point) *projPoint {
+	if p.IsInfinity {
+		return r.setInfinity()
+	}
+
+	r.x.setInt(p.X().Num)
+	r.y.setInt(p.Y().Num)
+	r.z = fieldVal{1}
+
+	return r
+}
+
+// toPoint sets z to the affine form of r and returns z.
+func (r *projPoint) toPoint(z *Point) *Point {
+	if r.z.isZero() {
+		z.point().Set(ellipticcurve.NewInfinityPoint(Curve))
+
+		return z
+	}
+
+	var zInv, x, y fieldVal
+	zInv.inverse(&r.z)
+	x.mul(&r.x, &zInv)
+	y.mul(&r.y, &zInv)
+
+	var xe, ye FieldElement
+	xe.setVal(&x)
+	ye.setVal(&y)
+
+	p, err := ellipticcurve.NewPoint(xe.Element, ye.Element, Curve)
+	if err != nil {
+		// The formulas always produce a point on the curve.
+		panic(err)
+	}
+
+	z.point().Set(p)
+
+	return z
+}
+
+// add sets r = p + q and returns r. This is algorithm 7 of Renes, Costello and
+// Batina.
+func (r *projPoint) add(p, q *projPoint) *projPoint {
+	var t0, t1, t2, t3, t4, x3, y3, z3 fieldVal
+
+	t0.mul(&p.x, &q.x)
+	t1.mul(&p.y, &q.y)
+	t2.mul(&p.z, &q.z)
+	t3.add(&p.x, &p.y)
+	t4.add(&q.x, &q.y)
+	t3.mul(&t3, &t4)
+	t4.add(&t0, &t1)
+	t3.sub(&t3, &t4)
+	t4.add(&p.y, &p.z)
+	x3.add(&q.y, &q.z)
+	t4.mul(&t4, &x3)
+	x3.add(&t1, &t2)
+	t4.sub(&t4, &x3)
+	x3.add(&p.x, &p.z)
+	y3.add(&q.x, &q.z)
+	x3.mul(&x3, &y3)
+	y3.add(&t0, &t2)
+	y3.sub(&x3, &y3)
+	x3.add(&t0, &t0)
+	t0.add(&x3, &t0)
+	t2.mul(&b3, &t2)
+	z3.add(&t1, &t2)
+	t1.sub(&t1, &t2)
+	y3.mul(&b3, &y3)
+	x3.mul(&t4, &y3)
+	t2.mul(&t3, &t1)
+	x3.sub(&t2, &x3)
+	y3.mul(&y3, &t0)
+	t1.mul(&t1, &z3)
+	y3.add(&t1, &y3)
+	t0.mul(&t0, &t3)
+	z3.mul(&z3, &t4)
+	z3.add(&z3, &t0)
+
+	r.x, r.y, r.z = x3, y3, z3
+
+	return r
+}
+
+// double sets r = p + p and returns r. This is algorithm 9 of Renes, Costello
+// and Batina.
+func (r *projPoint) double(p *projPoint) *projPoint {
+	var t0, t1, t2, x3, y3, z3 fieldVal
+
+	t0.square(&p.y)
+	z3.add(&t0, &t0)
+	z3.add(&z3, &z3)
+	z3.add(&z3, &z3)
+	t1.mul(&p.y, &p.z)
+	t2.square(&p.z)
+	t2.mul(&b3, &t2)
+	x3.mul(&t2, &z3)
+	y3.add(&t0, &t2)
+	z3.mul(&t1, &z3)
+	t1.add(&t2, &t2)
+	t2.add(&t1, &t2)
+	t0.sub(&t0, &t2)
+	y3.mul(&t0, &y3)
+	y3.add(&x3, &y3)
+	t1.mul(&p.x, &p.y)
+	x3.mul(&t0, &t1)
+	x3.add(&x3, &x3)
+
+	r.x, r.y, r.z = x3, y3, z3
+
+	return r
+}
+
+// lookup sets r to table[idx] and returns r. Every entry of the table is read
+// so that the memory access pattern does not depend on idx.
+func (r *projPoint) lookup(table *[secretTableSize]projPoint,
+	idx uint64) *projPoint {
+
+	*r = projPoint{}
+	for i := range table {
+		// mask is all ones if i == idx and zero otherwise.
+		d := uint64(i) ^ idx
+		mask := ((d | -d) >> 63) - 1
+
+		r.x.cmov(&table[i].x, mask)
+		r.y.cmov(&table[i].y, mask)
+		r.z.cmov(&table[i].z, mask)
+	}
+
+	return r
+}
+
+// cmov sets f to a if mask is all ones and leaves f unchanged if mask is zero.
+func (f *fieldVal) cmov(a *fieldVal, mask uint64) {
+	for i := range f {
+		f[i] = (a[i] & mask) | (f[i] &^ mask)
+	}
+}
@@ -0,0 +1,46 @@
+package secp256k1
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestMulSecret checks the constant-time multiplication against Mul.
+func TestMulSecret(t *testing.T) {
+	points := []*Point{
+		G,
+		G.Mul(big.NewInt(98765)),
+		G.Neg(),
+		NewInfinityPoint(),
+	}
+
+	for _, p := range points {
+		for _, k := range scalarTestValues(t) {
+			exp := p.Mul(k)
+			res := p.MulSecret(ScalarFromInt(k))
+			require.True(t, exp.Equal(res), "%x", k)
+			require.Equal(t, exp.IsInfinity, res.IsInfinity)
+		}
+	}
+
+	// The complete formulas handle doubling and adding the inverse of a
+	// point without any special cases.
+	var a, b, r projPoint
+	a.setPoint(G)
+	b.setPoint(G.Neg())
+
+	require.True(t, r.add(&a, &a).toPoint(new(Point)).Equal(G.Add(G)))
+	require.True(t, r.double(&a).toPoint(new(Point)).Equal(G.Add(G)))
+	require.True(t, r.add(&a, &b).toPoint(new(Point)).IsInfinity)
+}
+
+func BenchmarkMulSecret(b *testing.B) {
+	k := ScalarFromInt(big.NewInt(1234567))
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		G.MulSecret(k)
+	}
+}
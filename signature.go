@@ -1,76 +1,135 @@
 package schnorr
 
 import (
+	"errors"
 	"fmt"
-	"github.com/ellemouton/schnorr/secp256k1"
 	"math/big"
+
+	"github.com/ellemouton/schnorr/ellipticcurve"
 )
 
 const SignatureSize = 64
 
-// Signature is a schnorr signature.
+// ErrGroupMismatch is returned when a signature is checked against public keys
+// of a different Group.
+var ErrGroupMismatch = errors.New("signature and pub key are in different " +
+	"groups")
+
+// Signature is a schnorr signature. Its Group is the Group of R.
 type Signature struct {
 	R *PublicKey
-	S *big.Int
+	S *Scalar
 }
 
-// NewSignature constructs a new signature.
+// NewSignature constructs a new signature. An error is returned if s is not in
+// the range [0, N) where N is the order of the Group of r.
+//
+// Deprecated: use NewSignatureFromScalar, which does not hold S in a variable
+// time big.Int.
 func NewSignature(r *PublicKey, s *big.Int) (*Signature, error) {
-	if s.Cmp(secp256k1.N) >= 0 {
+	g := r.Group()
+	if s.Sign() < 0 || s.Cmp(g.ScalarField().Modulus()) >= 0 {
 		return nil, fmt.Errorf("invalid S")
 	}
 
+	b := s.FillBytes(make([]byte, ScalarLen(g)))
+
+	return NewSignatureFromScalar(r, ScalarFromBytesReduce(g, b)), nil
+}
+
+// NewSignatureFromScalar constructs a new signature from R and S.
+func NewSignatureFromScalar(r *PublicKey, s *Scalar) *Signature {
 	return &Signature{
 		R: r,
 		S: s,
-	}, nil
+	}
 }
 
+// NewSignatureFromBytes parses a secp256k1 signature from its 64 byte
+// encoding.
 func NewSignatureFromBytes(b []byte) (*Signature, error) {
 	if len(b) != SignatureSize {
 		return nil, fmt.Errorf("wrong sig size")
 	}
 
-	R, err := ParseXOnlyPubKey(b[:32])
+	return NewSignatureFromBytesInGroup(Secp256k1, b)
+}
+
+// NewSignatureFromBytesInGroup parses a signature of the given Group from the
+// x-only encoding of R followed by the encoding of S.
+func NewSignatureFromBytesInGroup(g Group, b []byte) (*Signature, error) {
+	rLen := g.BaseField().ByteLen()
+	if len(b) != rLen+ScalarLen(g) {
+		return nil, fmt.Errorf("wrong sig size")
+	}
+
+	R, err := ParseXOnlyPubKeyInGroup(g, b[:rLen])
 	if err != nil {
 		return nil, err
 	}
 
-	var s big.Int
-	s.SetBytes(b[32:])
+	s, err := ScalarFromBytes(g, b[rLen:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid S: %w", err)
+	}
 
-	return NewSignature(R, &s)
+	return NewSignatureFromScalar(R, s), nil
 }
 
-// Bytes returns the byte representation of the signature.
+// Bytes returns the SignatureSize byte representation of a secp256k1
+// signature. It panics if the encoding of the signature in its Group has
+// another length, in which case BytesInGroup must be used.
 func (s *Signature) Bytes() [SignatureSize]byte {
-	var sig [SignatureSize]byte
-	copy(sig[:32], s.R.XOnlyBytes()[:])
-	copy(sig[32:], s.S.Bytes())
+	b := s.BytesInGroup()
+	if len(b) != SignatureSize {
+		panic("signature encoding is not SignatureSize bytes long")
+	}
 
-	return sig
+	var res [SignatureSize]byte
+	copy(res[:], b)
+
+	return res
+}
+
+// BytesInGroup returns the x-only encoding of R followed by the encoding of S,
+// which is the encoding parsed by NewSignatureFromBytesInGroup.
+func (s *Signature) BytesInGroup() []byte {
+	return append(s.R.XOnlyBytes(), s.S.Bytes()...)
 }
 
 // Verify checks if the signature is a valid schnorr signature for the given
 // public key and message.
 func (s *Signature) Verify(pk *PublicKey, msg []byte) error {
+	g := pk.Group()
+	if !sameGroup(g, s.R.Group()) {
+		return ErrGroupMismatch
+	}
+
+	if !s.S.InGroup(g) {
+		return ErrScalarNotInGroup
+	}
+
 	pkBytes := pk.XOnlyBytes()
-	P, err := ParseXOnlyPubKey(pkBytes[:])
+	P, err := ParseXOnlyPubKeyInGroup(g, pkBytes)
 	if err != nil {
 		return err
 	}
 
-	e := IntFromBytes(
-		TaggedHash(
-			Bip340ChallengeTag, s.R.XOnlyBytes()[:], pkBytes[:],
-			msg,
-		),
+	eHash := TaggedHash(
+		Bip340ChallengeTag, s.R.XOnlyBytes(), pkBytes, msg,
 	)
+	e := ScalarFromBytesReduce(g, eHash[:])
 
-	one := NewPublicKey(secp256k1.G.Mul(s.S))
-	two := P.Mul(e).Mul(big.NewInt(-1))
+	// R = s*G - e*P
+	r, err := g.MultiScalarMul(
+		[]*ellipticcurve.Point{g.Generator(), P.Point},
+		[]*Scalar{s.S, e.Negate()},
+	)
+	if err != nil {
+		return err
+	}
 
-	R := one.Add(two)
+	R := NewPublicKeyInGroup(g, r)
 
 	if !R.HasEvenY() {
 		return fmt.Errorf("R does not have even Y")
@@ -84,7 +143,8 @@ func (s *Signature) Verify(pk *PublicKey, msg []byte) error {
 }
 
 // BatchVerify does batch schnorr verification of the given set of pubkeys,
-// messages and signatures.
+// messages and signatures. As in Verify, only the X coordinates of the pubkeys
+// and of the R values of the signatures are used.
 //
 // NOTE: this currently is not secure since the coefficients have not been
 // applied yet and so is subject to a cancellation attack.
@@ -98,25 +158,65 @@ func BatchVerify(pks []*PublicKey, msgs [][]byte, sigs []*Signature) error {
 	// S = R + ed
 	// S = R + eP
 	// (s1+s2+s3+...)*G =? (R1+R2+R3+...) + (e1P1+e2P2+e3P3+...)
+	//
+	// The check is done with a single multi-scalar multiplication by
+	// moving everything to the left side and comparing with infinity.
+	if len(sigs) == 0 {
+		return nil
+	}
+
 	var (
-		sAcc  = new(big.Int)
-		rAcc  = NewInfinityPubKey()
-		epAcc = NewInfinityPubKey()
+		g       = pks[0].Group()
+		sAcc    = NewScalar(g, 0)
+		one     = NewScalar(g, 1)
+		points  = make([]*ellipticcurve.Point, 1, 2*len(sigs)+1)
+		scalars = make([]*Scalar, 1, 2*len(sigs)+1)
 	)
 	for i, sig := range sigs {
-		e := IntFromBytes(TaggedHash(
-			Bip340ChallengeTag, sig.R.XOnlyBytes()[:],
-			pks[i].XOnlyBytes()[:], msgs[i],
-		))
-
-		epAcc = epAcc.Add(pks[i].Mul(e))
-		rAcc = rAcc.Add(sig.R)
-		sAcc.Add(sAcc, sig.S)
+		if !sameGroup(g, pks[i].Group()) ||
+			!sameGroup(g, sig.R.Group()) {
+
+			return ErrGroupMismatch
+		}
+
+		if !sig.S.InGroup(g) {
+			return ErrScalarNotInGroup
+		}
+
+		// Only the X coordinates of R and P are committed to so, as in
+		// Verify, the points with even Y coordinates are used.
+		rBytes, pkBytes := sig.R.XOnlyBytes(), pks[i].XOnlyBytes()
+
+		R, err := ParseXOnlyPubKeyInGroup(g, rBytes)
+		if err != nil {
+			return err
+		}
+
+		P, err := ParseXOnlyPubKeyInGroup(g, pkBytes)
+		if err != nil {
+			return err
+		}
+
+		eHash := TaggedHash(
+			Bip340ChallengeTag, rBytes, pkBytes, msgs[i],
+		)
+		e := ScalarFromBytesReduce(g, eHash[:])
+
+		// R is moved to the left side by negating the point rather
+		// than by multiplying it by -1.
+		points = append(points, R.Point.Neg(), P.Point)
+		scalars = append(scalars, one, e.Negate())
+		sAcc = sAcc.Add(sig.S)
 	}
 
-	S := NewPublicKey(secp256k1.G.Mul(sAcc))
+	points[0], scalars[0] = g.Generator(), sAcc
+
+	sum, err := g.MultiScalarMul(points, scalars)
+	if err != nil {
+		return err
+	}
 
-	if S.Equal(rAcc.Add(epAcc)) {
+	if sum.IsInfinity {
 		return nil
 	}
 